@@ -0,0 +1,100 @@
+package main
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// deploymentFlags adds the flags that select a deployment by its uid, or by its application and environment.
+func deploymentFlags(fs *flag.FlagSet) func(ctx context.Context, c *webclient.Client) (string, error) {
+	id := fs.String("id", "", "uid of the deployment")
+	app := fs.String("app", "", "uid or short name of the application, instead of -id")
+	env := fs.String("env", "", "uid or short name of the environment, instead of -id")
+	return func(ctx context.Context, c *webclient.Client) (string, error) {
+		if *id != "" {
+			return *id, nil
+		}
+		if *app == "" || *env == "" {
+			return "", fmt.Errorf("either -id or both -app and -env are required")
+		}
+		applicationID, err := applicationUID(ctx, c, *app)
+		if err != nil {
+			return "", err
+		}
+		environmentID, err := environmentUID(ctx, c, *env)
+		if err != nil {
+			return "", err
+		}
+		deployments, err := c.FindApplicationDeploymentByApplicationAndEnvironment(ctx,
+			fmt.Sprintf("%s/applications/%s", c.BaseURL(), applicationID), fmt.Sprintf("%s/environments/%s", c.BaseURL(), environmentID))
+		if err != nil {
+			return "", err
+		}
+		if len(deployments.Embedded.ApplicationDeploymentResponses) == 0 {
+			return "", fmt.Errorf("application %s is not deployed in environment %s", *app, *env)
+		}
+		return deployments.Embedded.ApplicationDeploymentResponses[0].Uid, nil
+	}
+}
+
+func deploymentStatus(ctx context.Context, args []string) error {
+	fs, s := newFlagSet("deployment status")
+	deployment := deploymentFlags(fs)
+	if err := s.parse(fs, args); err != nil {
+		return err
+	}
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := deployment(ctx, c)
+	if err != nil {
+		return err
+	}
+	return printDeploymentStatus(ctx, c, s, id)
+}
+
+// deploymentOperation returns the subcommand that starts or stops a deployment and shows its status after.
+func deploymentOperation(action string) command {
+	return func(ctx context.Context, args []string) error {
+		fs, s := newFlagSet("deployment " + action)
+		deployment := deploymentFlags(fs)
+		if err := s.parse(fs, args); err != nil {
+			return err
+		}
+		c, err := s.client(ctx)
+		if err != nil {
+			return err
+		}
+		id, err := deployment(ctx, c)
+		if err != nil {
+			return err
+		}
+		err = c.OperateApplicationDeployment(ctx, id, action, webclient.ApplicationDeploymentOperationRequest{Action: action})
+		if errors.Is(err, webclient.InvalidDeploymentStateError) {
+			fmt.Fprintf(stderr, "Deployment %s is already %s\n", id, map[string]string{"START": "started", "STOP": "stopped"}[action])
+		} else if err != nil {
+			return err
+		}
+		return printDeploymentStatus(ctx, c, s, id)
+	}
+}
+
+func printDeploymentStatus(ctx context.Context, c *webclient.Client, s *settings, id string) error {
+	status, err := c.GetApplicationDeploymentStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.print(status, []string{"DEPLOYMENT", "CONNECTOR STATE", "KSML STATUS"},
+		[][]string{{id, valueOr(status.ConnectorState.State), valueOr(status.KsmlStatus.Status)}})
+}
+
+func valueOr(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"strings"
+)
+
+func grantsList(ctx context.Context, args []string) error {
+	fs, s := newFlagSet("grants list")
+	app := fs.String("app", "", "uid or short name of the application")
+	env := fs.String("env", "", "uid or short name of the environment")
+	topic := fs.String("topic", "", "uid or name of the topic")
+	status := fs.String("status", "", "comma separated statuses, e.g. PENDING or APPROVED,REVOKED")
+	accessType := fs.String("access-type", "", "Consumer or Producer")
+	if err := s.parse(fs, args); err != nil {
+		return err
+	}
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	attributes := webclient.ApplicationAccessGrantAttributes{
+		AccessType: *accessType,
+		Statuses:   strings.ToUpper(*status),
+	}
+	if attributes.ApplicationId, err = applicationUID(ctx, c, *app); err != nil {
+		return err
+	}
+	if attributes.EnvironmentId, err = environmentUID(ctx, c, *env); err != nil {
+		return err
+	}
+	if attributes.TopicId, err = topicUID(ctx, c, *topic); err != nil {
+		return err
+	}
+	grants, err := c.GetApplicationAccessGrantsByAttributes(ctx, attributes)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, grant := range grants.Embedded.ApplicationAccessGrantResponses {
+		rows = append(rows, []string{grant.Uid, grant.Status, grant.AccessType,
+			grant.Embedded.Application.Uid, grant.Embedded.Stream.Uid, grant.Embedded.Environment.Uid})
+	}
+	return s.print(grants, []string{"UID", "STATUS", "ACCESS TYPE", "APPLICATION", "TOPIC", "ENVIRONMENT"}, rows)
+}
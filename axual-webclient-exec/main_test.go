@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const (
+	applicationID = "0123456789abcdef0123456789abcdef"
+	environmentID = "fedcba9876543210fedcba9876543210"
+	deploymentID  = "aaaabbbbccccddddeeeeffff00001111"
+)
+
+// newAPI returns a server answering the requests of the subcommands with the responses by method and URI,
+// given the URL of the server. The requests the server received are added to requests.
+func newAPI(t *testing.T, responses func(serverURL string) map[string]string, requests *[]string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected the static token, got %q", got)
+		}
+		request := r.Method + " " + r.URL.RequestURI()
+		if requests != nil {
+			*requests = append(*requests, request)
+		}
+		body, ok := responses(server.URL)[request]
+		if !ok {
+			t.Errorf("unexpected request %s", request)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body, "Invalid action") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// run runs the subcommand and returns what it wrote to stdout and stderr.
+func run(t *testing.T, cmd command, args ...string) (string, string, error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	previousOut, previousErr := stdout, stderr
+	stdout, stderr = &out, &errOut
+	t.Cleanup(func() { stdout, stderr = previousOut, previousErr })
+	err := cmd(context.Background(), append([]string{"-access_token", "token"}, args...))
+	return out.String(), errOut.String(), err
+}
+
+// deploymentResponses are the responses that resolve the deployment of application app in environment dev.
+func deploymentResponses(serverURL string) map[string]string {
+	return map[string]string{
+		"GET /applications/search/findByShortName?shortName=app": `{"uid":"` + applicationID + `","shortName":"app"}`,
+		"GET /environments/search/findByShortName?shortName=dev": `{"_embedded":{"environments":[{"uid":"` + environmentID + `","shortName":"dev"}]}}`,
+		"GET /application_deployments/search/findByApplicationAndEnvironment?application=" +
+			url.QueryEscape(serverURL+"/applications/"+applicationID) + "&environment=" +
+			url.QueryEscape(serverURL+"/environments/"+environmentID): `{"_embedded":{"application_deployments":[{"uid":"` + deploymentID + `"}]}}`,
+		"GET /application_deployments/" + deploymentID + "/status": `{"connectorState":{"state":"RUNNING"},"ksmlStatus":{}}`,
+	}
+}
+
+func TestSettingsParse(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		env     string
+		args    []string
+		wantErr string
+	}{
+		{
+			desc: "the API URL is taken from the flag",
+			args: []string{"-apiurl", "https://platform.local/api"},
+		},
+		{
+			desc: "the API URL defaults to AXUAL_API_URL",
+			env:  "https://platform.local/api",
+		},
+		{
+			desc:    "the API URL is required",
+			wantErr: "the API URL is not set",
+		},
+		{
+			desc:    "the output is table or json",
+			args:    []string{"-apiurl", "https://platform.local/api", "-output", "yaml"},
+			wantErr: `invalid output "yaml"`,
+		},
+		{
+			desc:    "arguments after the flags are rejected",
+			args:    []string{"-apiurl", "https://platform.local/api", "extra"},
+			wantErr: "unexpected arguments: extra",
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			t.Setenv("AXUAL_API_URL", c.env)
+			fs, s := newFlagSet("test")
+			err := s.parse(fs, c.args)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("expected an error with %q, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.apiURL != "https://platform.local/api" || s.realm != "axual" || s.clientID != "self-service" || s.output != "table" {
+				t.Errorf("expected the defaults, got %+v", s)
+			}
+		})
+	}
+}
+
+func TestTopicsList(t *testing.T) {
+	server := newAPI(t, func(string) map[string]string {
+		return map[string]string{
+			"GET /streams": `{"_embedded":{"streams":[` +
+				`{"uid":"1","name":"orders","keyType":"String","valueType":"AVRO","retentionPolicy":"delete","_embedded":{"owners":{"name":"team"}}},` +
+				`{"uid":"2","name":"payments","keyType":"String","valueType":"JSON","retentionPolicy":"compact","_embedded":{"owners":{"name":"team"}}}]}}`,
+		}
+	}, nil)
+
+	out, _, err := run(t, topicsList, "-apiurl", server.URL, "-output", "json", "-name", "order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var topics struct {
+		Embedded struct {
+			Topics []struct {
+				Uid  string `json:"uid"`
+				Name string `json:"name"`
+			} `json:"streams"`
+		} `json:"_embedded"`
+	}
+	if err := json.Unmarshal([]byte(out), &topics); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", out, err)
+	}
+	if len(topics.Embedded.Topics) != 1 || topics.Embedded.Topics[0].Name != "orders" {
+		t.Errorf("expected only the matching topic, got %+v", topics.Embedded.Topics)
+	}
+
+	out, _, err = run(t, topicsList, "-apiurl", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "UID") || !strings.Contains(lines[2], "payments") {
+		t.Errorf("expected a table with a row per topic, got %q", out)
+	}
+}
+
+func TestDeploymentStatus(t *testing.T) {
+	server := newAPI(t, deploymentResponses, nil)
+
+	out, _, err := run(t, deploymentStatus, "-apiurl", server.URL, "-app", "app", "-env", "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[1]), " ") != deploymentID+" RUNNING -" {
+		t.Errorf("expected the status of the deployment, got %q", out)
+	}
+
+	if _, _, err := run(t, deploymentStatus, "-apiurl", server.URL, "-app", "app"); err == nil {
+		t.Error("expected an error without the environment")
+	}
+}
+
+func TestDeploymentOperation(t *testing.T) {
+	operation := "PUT /application_deployments/" + deploymentID + "/operation?action=START"
+	testCases := []struct {
+		desc     string
+		response string
+		wantNote string
+	}{
+		{
+			desc:     "the deployment is started",
+			response: ``,
+		},
+		{
+			desc:     "a started deployment is reported and its status shown",
+			response: `{"message":"Invalid action for this state of deployment"}`,
+			wantNote: "Deployment " + deploymentID + " is already started\n",
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var requests []string
+			server := newAPI(t, func(serverURL string) map[string]string {
+				responses := deploymentResponses(serverURL)
+				responses[operation] = c.response
+				return responses
+			}, &requests)
+
+			out, errOut, err := run(t, deploymentOperation("START"), "-apiurl", server.URL, "-id", deploymentID, "-output", "json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if errOut != c.wantNote {
+				t.Errorf("expected the note %q, got %q", c.wantNote, errOut)
+			}
+			if len(requests) != 2 || requests[0] != operation {
+				t.Errorf("expected the operation and the status requests, got %v", requests)
+			}
+			if !strings.Contains(out, `"state": "RUNNING"`) {
+				t.Errorf("expected the status of the deployment, got %q", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// stdout and stderr are where the subcommands write their output and notes.
+var stdout, stderr io.Writer = os.Stdout, os.Stderr
+
+// print writes the response as indented JSON, or as a table with a row per element of rows.
+func (s *settings) print(response interface{}, header []string, rows [][]string) error {
+	if s.output == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(response)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
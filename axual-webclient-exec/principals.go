@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+func principalFind(ctx context.Context, args []string) error {
+	fs, s := newFlagSet("principal find")
+	app := fs.String("app", "", "uid or short name of the application")
+	env := fs.String("env", "", "uid or short name of the environment")
+	if err := s.parse(fs, args); err != nil {
+		return err
+	}
+	if *app == "" || *env == "" {
+		return fmt.Errorf("-app and -env are required")
+	}
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	applicationID, err := applicationUID(ctx, c, *app)
+	if err != nil {
+		return err
+	}
+	environmentID, err := environmentUID(ctx, c, *env)
+	if err != nil {
+		return err
+	}
+	principals, err := c.FindApplicationPrincipalByApplicationAndEnvironment(ctx,
+		fmt.Sprintf("%s/applications/%s", c.BaseURL(), applicationID), fmt.Sprintf("%s/environments/%s", c.BaseURL(), environmentID))
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, principal := range principals.Embedded.ApplicationPrincipalResponses {
+		active := "-"
+		if principal.Active != nil {
+			active = fmt.Sprint(*principal.Active)
+		}
+		rows = append(rows, []string{principal.Uid, principal.Type, active, principal.Principal})
+	}
+	return s.print(principals, []string{"UID", "TYPE", "ACTIVE", "PRINCIPAL"}, rows)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// uidPattern matches the uids of the platform, which are accepted wherever a name is.
+var uidPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// applicationUID returns the uid of the application with the uid or short name.
+func applicationUID(ctx context.Context, c *webclient.Client, reference string) (string, error) {
+	if reference == "" || uidPattern.MatchString(reference) {
+		return reference, nil
+	}
+	application, err := c.GetApplicationByNameOrShortName(ctx, url.Values{"shortName": {reference}})
+	if err != nil {
+		return "", fmt.Errorf("application %s: %w", reference, err)
+	}
+	return application.Uid, nil
+}
+
+// environmentUID returns the uid of the environment with the uid or short name.
+func environmentUID(ctx context.Context, c *webclient.Client, reference string) (string, error) {
+	if reference == "" || uidPattern.MatchString(reference) {
+		return reference, nil
+	}
+	environments, err := c.GetEnvironmentByShortName(ctx, reference)
+	if err != nil {
+		return "", fmt.Errorf("environment %s: %w", reference, err)
+	}
+	if len(environments.Embedded.Environments) == 0 {
+		return "", fmt.Errorf("environment %s: %w", reference, webclient.NotFoundError)
+	}
+	return environments.Embedded.Environments[0].Uid, nil
+}
+
+// topicUID returns the uid of the topic with the uid or name.
+func topicUID(ctx context.Context, c *webclient.Client, reference string) (string, error) {
+	if reference == "" || uidPattern.MatchString(reference) {
+		return reference, nil
+	}
+	topics, err := c.GetTopicByName(ctx, reference)
+	if err != nil {
+		return "", fmt.Errorf("topic %s: %w", reference, err)
+	}
+	if len(topics.Embedded.Topics) == 0 {
+		return "", fmt.Errorf("topic %s: %w", reference, webclient.NotFoundError)
+	}
+	return topics.Embedded.Topics[0].Uid, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// settings holds the flags of every subcommand: the provider settings used to sign in, and the output format.
+// The flags have the names of the provider attributes, and default to the environment variables the provider
+// reads, or to AXUAL_<NAME> for the attributes the provider only takes from its configuration.
+type settings struct {
+	apiURL             string
+	authURL            string
+	realm              string
+	authMode           string
+	clientID           string
+	issuer             string
+	audience           string
+	scopes             string
+	username           string
+	password           string
+	grantType          string
+	clientSecret       string
+	clientAssertionKey string
+	accessToken        string
+	accessTokenFile    string
+	caCertFile         string
+	insecureSkipVerify bool
+	proxyURL           string
+
+	output string
+}
+
+// newFlagSet returns the flag set of a subcommand with the settings flags.
+func newFlagSet(name string) (*flag.FlagSet, *settings) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	s := &settings{}
+	fs.StringVar(&s.apiURL, "apiurl", os.Getenv("AXUAL_API_URL"), "URL of the API, e.g. https://platform.local/api (AXUAL_API_URL)")
+	fs.StringVar(&s.authURL, "authurl", os.Getenv("AXUAL_AUTH_URL"), "URL of the token endpoint (AXUAL_AUTH_URL)")
+	fs.StringVar(&s.realm, "realm", envOr("AXUAL_REALM", "axual"), "realm of the tenant (AXUAL_REALM)")
+	fs.StringVar(&s.authMode, "authmode", os.Getenv("AXUAL_AUTH_MODE"), "keycloak or auth0, keycloak by default unless issuer is set (AXUAL_AUTH_MODE)")
+	fs.StringVar(&s.clientID, "clientid", envOr("AXUAL_CLIENT_ID", "self-service"), "OAuth client ID (AXUAL_CLIENT_ID)")
+	fs.StringVar(&s.issuer, "issuer", os.Getenv("AXUAL_ISSUER"), "OpenID Connect issuer URL to discover the token endpoint (AXUAL_ISSUER)")
+	fs.StringVar(&s.audience, "audience", os.Getenv("AXUAL_AUDIENCE"), "audience of the token, for auth0 (AXUAL_AUDIENCE)")
+	fs.StringVar(&s.scopes, "scopes", envOr("AXUAL_SCOPES", "openid,profile,email"), "comma separated scopes (AXUAL_SCOPES)")
+	fs.StringVar(&s.username, "username", os.Getenv("AXUAL_AUTH_USERNAME"), "username (AXUAL_AUTH_USERNAME)")
+	fs.StringVar(&s.password, "password", os.Getenv("AXUAL_AUTH_PASSWORD"), "password (AXUAL_AUTH_PASSWORD)")
+	fs.StringVar(&s.grantType, "grant_type", envOr("AXUAL_AUTH_GRANT_TYPE", "password"), "password, client_credentials, token_exchange or jwt_bearer (AXUAL_AUTH_GRANT_TYPE)")
+	fs.StringVar(&s.clientSecret, "client_secret", os.Getenv("AXUAL_AUTH_CLIENT_SECRET"), "client secret (AXUAL_AUTH_CLIENT_SECRET)")
+	fs.StringVar(&s.clientAssertionKey, "client_assertion_key_file", "", "private key file for private_key_jwt client authentication; AXUAL_AUTH_CLIENT_ASSERTION_KEY holds the PEM")
+	fs.StringVar(&s.accessToken, "access_token", os.Getenv("AXUAL_AUTH_ACCESS_TOKEN"), "static bearer token instead of signing in (AXUAL_AUTH_ACCESS_TOKEN)")
+	fs.StringVar(&s.accessTokenFile, "access_token_file", os.Getenv("AXUAL_AUTH_ACCESS_TOKEN_FILE"), "file with a bearer token instead of signing in (AXUAL_AUTH_ACCESS_TOKEN_FILE)")
+	fs.StringVar(&s.caCertFile, "ca_cert_file", os.Getenv("AXUAL_CA_CERT_FILE"), "PEM file with the CA certificates of the platform (AXUAL_CA_CERT_FILE)")
+	fs.BoolVar(&s.insecureSkipVerify, "insecure_skip_verify", os.Getenv("AXUAL_INSECURE_SKIP_VERIFY") == "true", "do not verify the certificate of the platform (AXUAL_INSECURE_SKIP_VERIFY)")
+	fs.StringVar(&s.proxyURL, "proxy_url", os.Getenv("AXUAL_PROXY_URL"), "URL of an HTTP proxy (AXUAL_PROXY_URL)")
+	fs.StringVar(&s.output, "output", "table", "output format, table or json")
+	return fs, s
+}
+
+// parse parses the flags and checks the settings every subcommand needs.
+func (s *settings) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if s.output != "table" && s.output != "json" {
+		return fmt.Errorf("invalid output %q, expected table or json", s.output)
+	}
+	if s.apiURL == "" {
+		return fmt.Errorf("the API URL is not set, use -apiurl or AXUAL_API_URL")
+	}
+	return nil
+}
+
+// client signs in like the provider does with the same settings.
+func (s *settings) client(ctx context.Context) (*webclient.Client, error) {
+	auth := webclient.AuthStruct{
+		Username:        s.username,
+		Password:        s.password,
+		Url:             s.authURL,
+		ClientId:        s.clientID,
+		Audience:        s.audience,
+		AuthMode:        s.authMode,
+		Issuer:          s.issuer,
+		GrantType:       s.grantType,
+		ClientSecret:    s.clientSecret,
+		PrivateKeyPEM:   os.Getenv("AXUAL_AUTH_CLIENT_ASSERTION_KEY"),
+		PrivateKeyFile:  s.clientAssertionKey,
+		SubjectTokenEnv: "AXUAL_AUTH_SUBJECT_TOKEN",
+		AccessToken:     s.accessToken,
+		AccessTokenFile: s.accessTokenFile,
+	}
+	if auth.AuthMode == "" && auth.Issuer == "" {
+		auth.AuthMode = "keycloak"
+	}
+	if s.scopes != "" {
+		auth.Scopes = strings.Split(s.scopes, ",")
+	}
+	return webclient.NewClient(ctx, s.apiURL, s.realm, auth,
+		webclient.WithTLSConfig(webclient.TLSConfig{CACertFile: s.caCertFile, InsecureSkipVerify: s.insecureSkipVerify}),
+		webclient.WithProxy(webclient.ProxyConfig{URL: s.proxyURL}),
+	)
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"strings"
+)
+
+func topicsList(ctx context.Context, args []string) error {
+	fs, s := newFlagSet("topics list")
+	name := fs.String("name", "", "only list the topics with this text in their name")
+	if err := s.parse(fs, args); err != nil {
+		return err
+	}
+	c, err := s.client(ctx)
+	if err != nil {
+		return err
+	}
+	topics, err := c.ListTopics(ctx)
+	if err != nil {
+		return err
+	}
+
+	matching := topics.Embedded.Topics[:0]
+	for _, topic := range topics.Embedded.Topics {
+		if strings.Contains(topic.Name, *name) {
+			matching = append(matching, topic)
+		}
+	}
+	topics.Embedded.Topics = matching
+	var rows [][]string
+	for _, topic := range matching {
+		rows = append(rows, []string{topic.Uid, topic.Name, topic.Embedded.Owners.Name, topic.KeyType, topic.ValueType, topic.RetentionPolicy})
+	}
+	return s.print(topics, []string{"UID", "NAME", "OWNERS", "KEY TYPE", "VALUE TYPE", "RETENTION POLICY"}, rows)
+}
@@ -0,0 +1,146 @@
+package webclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// AxualAPI is the self-service API as used by the provider, grouped by domain. Client implements it;
+// tests implement it with fakes, often by embedding the interface and overriding the methods they need.
+type AxualAPI interface {
+	TopicsAPI
+	EnvironmentsAPI
+	ApplicationsAPI
+	GrantsAPI
+	SchemasAPI
+	DeploymentsAPI
+	GroupsAPI
+	UsersAPI
+	PlatformAPI
+
+	// BaseURL returns the URL of the API, used to reference other resources by URL in requests.
+	BaseURL() string
+}
+
+var _ AxualAPI = (*Client)(nil)
+
+// TopicsAPI manages topics and their configs per environment, including browse permissions.
+type TopicsAPI interface {
+	GetTopic(ctx context.Context, id string) (*TopicResponse, error)
+	GetTopicByName(ctx context.Context, name string) (*TopicsByNameResponse, error)
+	ListTopics(ctx context.Context) (*TopicsResponse, error)
+	CreateTopic(ctx context.Context, topic TopicRequest) (*TopicResponse, error)
+	UpdateTopic(ctx context.Context, id string, topic TopicRequest) (*TopicResponse, error)
+	DeleteTopic(ctx context.Context, id string) error
+
+	ReadTopicConfig(ctx context.Context, id string) (*TopicConfigResponse, error)
+	ListTopicConfigs(ctx context.Context) (*TopicConfigsResponse, error)
+	CreateTopicConfig(ctx context.Context, topic TopicConfigRequest) (*TopicConfigResponse, error)
+	UpdateTopicConfig(ctx context.Context, id string, topic TopicConfigRequest) (*TopicConfigResponse, error)
+	DeleteTopicConfig(ctx context.Context, id string) error
+
+	GetTopicConfigPermissions(ctx context.Context, topicConfigID string, permType string) ([]PermissionResponse, error)
+	AddTopicConfigPermissions(ctx context.Context, topicConfigID string, request PermissionRequest) error
+	DeleteTopicConfigPermissions(ctx context.Context, topicConfigID string, request PermissionRequest) error
+}
+
+// EnvironmentsAPI manages environments and looks up the instances they run on.
+type EnvironmentsAPI interface {
+	GetEnvironment(ctx context.Context, id string) (*EnvironmentResponse, error)
+	GetEnvironments(ctx context.Context) (*EnvironmentsResponse, error)
+	GetEnvironmentByName(ctx context.Context, name string) (*EnvironmentsResponse, error)
+	GetEnvironmentByShortName(ctx context.Context, name string) (*EnvironmentsResponse, error)
+	CreateEnvironment(ctx context.Context, env EnvironmentRequest) (*EnvironmentResponse, error)
+	UpdateEnvironment(ctx context.Context, id string, env EnvironmentRequest) (*EnvironmentResponse, error)
+	DeleteEnvironment(ctx context.Context, id string) error
+
+	GetInstanceByName(ctx context.Context, name string) (*InstanceResponse, error)
+	GetInstanceByShortName(ctx context.Context, shortName string) (*InstanceResponse, error)
+}
+
+// ApplicationsAPI manages applications and their principals and credentials per environment.
+type ApplicationsAPI interface {
+	GetApplication(ctx context.Context, id string) (*ApplicationResponse, error)
+	ListApplications(ctx context.Context) (*ApplicationsResponse, error)
+	GetApplicationByNameOrShortName(ctx context.Context, params url.Values) (*ApplicationResponse, error)
+	CreateApplication(ctx context.Context, data ApplicationRequest) (*ApplicationResponse, error)
+	UpdateApplication(ctx context.Context, id string, data ApplicationRequest) (*ApplicationResponse, error)
+	DeleteApplication(ctx context.Context, id string) error
+
+	ReadApplicationPrincipal(ctx context.Context, id string) (*ApplicationPrincipalResponse, error)
+	FindApplicationPrincipalByApplicationAndEnvironment(ctx context.Context, application string, environment string) (*ApplicationPrincipalFindByApplicationAndEnvironmentResponse, error)
+	CreateApplicationPrincipal(ctx context.Context, applicationPrincipalRequest [1]ApplicationPrincipalRequest) (ApplicationPrincipalCreateResponse, error)
+	ActivateApplicationPrincipal(ctx context.Context, id string) error
+	DeleteApplicationPrincipal(ctx context.Context, id string) error
+
+	ReadApplicationCredential(ctx context.Context, id string) (*ApplicationCredentialFindByApplicationAndEnvironmentResponse, error)
+	FindApplicationCredentialByApplicationAndEnvironment(ctx context.Context, application string, environment string) ([]ApplicationCredentialFindByApplicationAndEnvironmentResponse, error)
+	CreateApplicationCredential(ctx context.Context, applicationCredentialRequest ApplicationCredentialCreateRequest) (ApplicationCredentialResponse, error)
+	DeleteApplicationCredential(ctx context.Context, applicationCredentialDeleteRequest ApplicationCredentialDeleteRequest) error
+}
+
+// GrantsAPI manages the access of applications to topics and its approval workflow.
+type GrantsAPI interface {
+	GetApplicationAccessGrant(ctx context.Context, id string) (*ApplicationAccessGrant, error)
+	GetApplicationAccessGrantsByAttributes(ctx context.Context, data ApplicationAccessGrantAttributes) (*GetApplicationAccessGrantsByAttributeResponse, error)
+	CreateApplicationAccessGrant(ctx context.Context, data ApplicationAccessGrantRequest) (*ApplicationAccessGrantResponse, error)
+	ApproveGrant(ctx context.Context, applicationAccessGrantId string) error
+	CancelGrant(ctx context.Context, applicationAccessGrantId string) error
+	RevokeOrDenyGrant(ctx context.Context, applicationAccessGrantId string, reason string) error
+}
+
+// SchemasAPI manages schemas and their versions.
+type SchemasAPI interface {
+	GetSchemaByName(ctx context.Context, name string) (*GetSchemaByNameResponse, error)
+	GetSchemaVersion(ctx context.Context, id string) (*GetSchemaVersionResponse, error)
+	GetKeySchemaVersion(ctx context.Context, id string) (*GetSchemaVersionResponse, error)
+	GetValueSchemaVersion(ctx context.Context, id string) (*GetSchemaVersionResponse, error)
+	GetSchemaVersionsBySchema(ctx context.Context, id string) (*GetSchemaVersionsResponse, error)
+	ValidateSchemaVersion(ctx context.Context, schema ValidateSchemaVersionRequest) (*ValidateSchemaVersionResponse, error)
+	CreateSchemaVersion(ctx context.Context, data SchemaVersionRequest) (*CreateSchemaVersionResponse, error)
+	DeleteSchemaVersion(ctx context.Context, id string) error
+}
+
+// DeploymentsAPI manages the deployments of Connector and KSML applications and starts and stops them.
+type DeploymentsAPI interface {
+	GetApplicationDeployment(ctx context.Context, id string) (*ApplicationDeploymentResponse, error)
+	FindApplicationDeploymentByApplicationAndEnvironment(ctx context.Context, application string, environment string) (*ApplicationDeploymentFindByApplicationAndEnvironmentResponse, error)
+	GetApplicationDeploymentStatus(ctx context.Context, id string) (*ApplicationDeploymentStatusResponse, error)
+	CreateApplicationDeployment(ctx context.Context, applicationDeploymentRequest ApplicationDeploymentCreateRequest) (ApplicationDeploymentCreateResponse, error)
+	UpdateApplicationDeployment(ctx context.Context, id string, data ApplicationDeploymentUpdateRequest) (ApplicationDeploymentUpdateResponse, error)
+	OperateApplicationDeployment(ctx context.Context, id string, action string, data ApplicationDeploymentOperationRequest) error
+	WaitForApplicationDeploymentOperation(ctx context.Context, id string, action string) error
+	DeleteApplicationDeployment(ctx context.Context, id string) error
+}
+
+// GroupsAPI manages groups.
+type GroupsAPI interface {
+	GetGroup(ctx context.Context, id string) (*GroupResponse, error)
+	ListGroups(ctx context.Context) (*GroupsResponse, error)
+	GetGroupByName(ctx context.Context, name string) (*GetGroupByNameResponse, error)
+	CreateGroup(ctx context.Context, group GroupRequest) (*GroupResponse, error)
+	UpdateGroup(ctx context.Context, id string, group GroupRequest) (*GroupResponse, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+// UsersAPI manages existing users; users are created by signing in to the platform.
+type UsersAPI interface {
+	GetUser(ctx context.Context, id string) (*UserResponse, error)
+	FindUserByEmail(ctx context.Context, email string) (*UsersResponse, error)
+	UpdateUser(ctx context.Context, id string, data UserRequest) (*UserResponse, error)
+	UpdateUserRoles(ctx context.Context, id string, data []UserRole) error
+	DeleteUser(ctx context.Context, id string) error
+}
+
+// PlatformAPI describes the connected platform and the features it supports.
+type PlatformAPI interface {
+	DetectPlatform(ctx context.Context) (*PlatformInfo, error)
+	Platform() *PlatformInfo
+	Supports(feature Feature) bool
+	RequireFeature(feature Feature) error
+}
+
+// BaseURL returns the URL of the API.
+func (c *Client) BaseURL() string {
+	return c.ApiURL
+}
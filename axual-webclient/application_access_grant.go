@@ -1,24 +1,25 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
 	"strings"
-	"time"
 )
 
-func (c *Client) GetApplicationAccessGrant(id string) (*ApplicationAccessGrant, error) {
+func (c *Client) GetApplicationAccessGrant(ctx context.Context, id string) (*ApplicationAccessGrant, error) {
 	o := ApplicationAccessGrant{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/application_access_grants/%v", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/application_access_grants/%v", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) CreateApplicationAccessGrant(data ApplicationAccessGrantRequest) (*ApplicationAccessGrantResponse, error) {
+func (c *Client) CreateApplicationAccessGrant(ctx context.Context, data ApplicationAccessGrantRequest) (*ApplicationAccessGrantResponse, error) {
 	h := make(map[string]string)
 	h["accept"] = "application/json, text/plain, */*"
 	h["content-type"] = "application/json"
@@ -30,39 +31,60 @@ func (c *Client) CreateApplicationAccessGrant(data ApplicationAccessGrantRequest
 		return nil, err
 	}
 
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/application_access_grants", c.ApiURL), strings.NewReader(string(marshal)), h, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/application_access_grants", c.ApiURL), strings.NewReader(string(marshal)), h, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) ApproveGrant(applicationAccessGrantId string) error {
+func (c *Client) ApproveGrant(ctx context.Context, applicationAccessGrantId string) error {
 	h := make(map[string]string)
 	h["accept"] = "application/json, text/plain, */*"
 	h["content-type"] = "application/json"
-	err := c.RequestAndMap("PUT", fmt.Sprintf("%s/application_access_grants/%v", c.ApiURL, applicationAccessGrantId), nil, h, nil)
+	err := c.RequestAndMap(ctx, "PUT", fmt.Sprintf("%s/application_access_grants/%v", c.ApiURL, applicationAccessGrantId), nil, h, nil)
 	if err != nil {
 
 		return err
 	}
-	time.Sleep(10 * time.Second) // Grant approval can take significant time
-	return nil
+	return c.waitForGrantStatus(ctx, applicationAccessGrantId, "Approved")
 }
 
-func (c *Client) CancelGrant(applicationAccessGrantId string) error {
+func (c *Client) CancelGrant(ctx context.Context, applicationAccessGrantId string) error {
 	h := make(map[string]string)
 	h["accept"] = "application/json, text/plain, */*"
 	h["content-type"] = "application/json"
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/application_access_grants/%v", c.ApiURL, applicationAccessGrantId), nil, h, nil)
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/application_access_grants/%v", c.ApiURL, applicationAccessGrantId), nil, h, nil)
 	if err != nil {
 		return err
 	}
-	time.Sleep(10 * time.Second) //to give time for Connect/Kafka to propagate changes
-	return nil
+	return c.waitForGrantStatus(ctx, applicationAccessGrantId, "Cancelled")
+}
+
+// waitForGrantStatus waits until the platform has processed the grant and reports the status.
+// A cancelled grant may also be removed. Other final statuses end the wait with an error.
+func (c *Client) waitForGrantStatus(ctx context.Context, id string, status string) error {
+	return c.waitFor(ctx, fmt.Sprintf("application access grant %s to be %s", id, status), func(ctx context.Context) (bool, error) {
+		grant, err := c.GetApplicationAccessGrant(ctx, id)
+		if errors.Is(err, NotFoundError) && status == "Cancelled" {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch grant.Status {
+		case status:
+			return true, nil
+		case "Pending":
+			return false, nil
+		case "Approved", "Rejected", "Revoked", "Cancelled":
+			return false, fmt.Errorf("application access grant %s is %s instead of %s", id, grant.Status, status)
+		}
+		return false, nil
+	})
 }
 
-func (c *Client) RevokeOrDenyGrant(applicationAccessGrantId string, reason string) error {
+func (c *Client) RevokeOrDenyGrant(ctx context.Context, applicationAccessGrantId string, reason string) error {
 	h := make(map[string]string)
 	h["accept"] = "application/json, text/plain, */*"
 	h["content-type"] = "application/json"
@@ -74,7 +96,7 @@ func (c *Client) RevokeOrDenyGrant(applicationAccessGrantId string, reason strin
 		return err1
 	}
 
-	err := c.RequestAndMap("POST", fmt.Sprintf("%s/application_access_grants/%v/deny", c.ApiURL, applicationAccessGrantId), strings.NewReader(string(marshal)), h, nil)
+	err := c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/application_access_grants/%v/deny", c.ApiURL, applicationAccessGrantId), strings.NewReader(string(marshal)), h, nil)
 	if err != nil {
 		return err
 	}
@@ -90,7 +112,7 @@ type ApplicationAccessGrantAttributes struct {
 	Size          int    `json:"size"`
 }
 
-func (c *Client) GetApplicationAccessGrantsByAttributes(data ApplicationAccessGrantAttributes) (*GetApplicationAccessGrantsByAttributeResponse, error) {
+func (c *Client) GetApplicationAccessGrantsByAttributes(ctx context.Context, data ApplicationAccessGrantAttributes) (*GetApplicationAccessGrantsByAttributeResponse, error) {
 	o := GetApplicationAccessGrantsByAttributeResponse{}
 	headers := map[string]string{
 		"Content-Type": "application/json",
@@ -113,7 +135,7 @@ func (c *Client) GetApplicationAccessGrantsByAttributes(data ApplicationAccessGr
 
 	endpoint := fmt.Sprintf("%s/application_access_grants/search/findByAttributes?%s", c.ApiURL, values.Encode())
 
-	err := c.RequestAndMap("GET", endpoint, nil, headers, &o)
+	err := c.RequestAndMapAllPages(ctx, endpoint, headers, &o)
 	if err != nil {
 		return nil, err
 	}
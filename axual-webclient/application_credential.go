@@ -1,57 +1,80 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 )
 
-func (c *Client) ReadApplicationCredential(id string) (*ApplicationCredentialFindByApplicationAndEnvironmentResponse, error) {
+func (c *Client) ReadApplicationCredential(ctx context.Context, id string) (*ApplicationCredentialFindByApplicationAndEnvironmentResponse, error) {
 	o := ApplicationCredentialFindByApplicationAndEnvironmentResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/application_credentials/%s", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/application_credentials/%s", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) CreateApplicationCredential(applicationCredentialRequest ApplicationCredentialCreateRequest) (ApplicationCredentialResponse, error) {
+func (c *Client) CreateApplicationCredential(ctx context.Context, applicationCredentialRequest ApplicationCredentialCreateRequest) (ApplicationCredentialResponse, error) {
 	var responseList ApplicationCredentialResponseList
 	marshal, err := json.Marshal(applicationCredentialRequest)
 	if err != nil {
 		return ApplicationCredentialResponse{}, fmt.Errorf("error creating payload for application credentials: %w", err)
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/application_authentications", c.ApiURL), strings.NewReader(string(marshal)), headers, &responseList)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/application_authentications", c.ApiURL), strings.NewReader(string(marshal)), headers, &responseList)
 	if err != nil {
 		return ApplicationCredentialResponse{}, fmt.Errorf("error sending POST request for application credentials: %w", err)
 	}
 
-	time.Sleep(2 * time.Second)
+	err = c.waitForApplicationCredential(ctx, applicationCredentialRequest.ApplicationId, applicationCredentialRequest.EnvironmentId, responseList[0].AuthData.Username, true)
+	if err != nil {
+		return ApplicationCredentialResponse{}, err
+	}
 	return responseList[0], nil
 }
 
-func (c *Client) DeleteApplicationCredential(applicationCredentialDeleteRequest ApplicationCredentialDeleteRequest) error {
+func (c *Client) DeleteApplicationCredential(ctx context.Context, applicationCredentialDeleteRequest ApplicationCredentialDeleteRequest) error {
 	marshal, err := json.Marshal(applicationCredentialDeleteRequest)
 	if err != nil {
 		return err
 	}
 
-	err = c.RequestAndMap("DELETE", fmt.Sprintf("%s/application_authentications", c.ApiURL), strings.NewReader(string(marshal)), nil, nil)
+	err = c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/application_authentications", c.ApiURL), strings.NewReader(string(marshal)), nil, nil)
 	if err != nil {
 		return err
 	}
-	time.Sleep(2 * time.Second) // Credential application can take significant time to apply in Kafka cluster
-	return nil
+	return c.waitForApplicationCredential(ctx, applicationCredentialDeleteRequest.ApplicationId, applicationCredentialDeleteRequest.EnvironmentId, applicationCredentialDeleteRequest.Configs.Username, false)
+}
+
+// waitForApplicationCredential waits until the credential with the username is, or is no longer, listed for the
+// application and environment.
+func (c *Client) waitForApplicationCredential(ctx context.Context, application string, environment string, username string, present bool) error {
+	description := fmt.Sprintf("application credential %s to be applied", username)
+	if !present {
+		description = fmt.Sprintf("application credential %s to be removed", username)
+	}
+	return c.waitFor(ctx, description, func(ctx context.Context) (bool, error) {
+		credentials, err := c.FindApplicationCredentialByApplicationAndEnvironment(ctx, application, environment)
+		if err != nil {
+			return false, err
+		}
+		for _, credential := range credentials {
+			if credential.Username == username {
+				return present, nil
+			}
+		}
+		return !present, nil
+	})
 }
 
-func (c *Client) FindApplicationCredentialByApplicationAndEnvironment(application string, environment string) ([]ApplicationCredentialFindByApplicationAndEnvironmentResponse, error) {
+func (c *Client) FindApplicationCredentialByApplicationAndEnvironment(ctx context.Context, application string, environment string) ([]ApplicationCredentialFindByApplicationAndEnvironmentResponse, error) {
 	var o []ApplicationCredentialFindByApplicationAndEnvironmentResponse
 
 	err :=
-		c.RequestAndMap("GET", fmt.Sprintf("%s/application_credentials/search/findByApplicationIdAndEnvironmentId?applicationId=%v&environmentId=%v",
+		c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/application_credentials/search/findByApplicationIdAndEnvironmentId?applicationId=%v&environmentId=%v",
 			c.ApiURL, url.QueryEscape(application), url.QueryEscape(environment)), nil, nil, &o)
 	if err != nil {
 		return nil, err
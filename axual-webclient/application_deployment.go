@@ -1,88 +1,134 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
-func (c *Client) GetApplicationDeployment(id string) (*ApplicationDeploymentResponse, error) {
+// InvalidDeploymentStateError is returned by OperateApplicationDeployment when the deployment
+// is already in the state the action would bring it to, e.g. starting a running deployment.
+var InvalidDeploymentStateError = errors.New("invalid action for this state of deployment")
+
+func (c *Client) GetApplicationDeployment(ctx context.Context, id string) (*ApplicationDeploymentResponse, error) {
 	o := ApplicationDeploymentResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/application_deployments/%v", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/application_deployments/%v", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) DeleteApplicationDeployment(id string) error {
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/application_deployments/%v", c.ApiURL, id), nil, nil, nil)
+func (c *Client) DeleteApplicationDeployment(ctx context.Context, id string) error {
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/application_deployments/%v", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) CreateApplicationDeployment(applicationDeploymentRequest ApplicationDeploymentCreateRequest) (ApplicationDeploymentCreateResponse, error) {
+func (c *Client) CreateApplicationDeployment(ctx context.Context, applicationDeploymentRequest ApplicationDeploymentCreateRequest) (ApplicationDeploymentCreateResponse, error) {
 	var o ApplicationDeploymentCreateResponse
 	marshal, err := json.Marshal(applicationDeploymentRequest)
 	if err != nil {
 		return "Error creating payload for application deployment", err
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/application_deployments", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/application_deployments", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
 	if err != nil {
 		return "Error sending POST request for application deployment", err
 	}
 	return o, nil
 }
 
-func (c *Client) UpdateApplicationDeployment(id string, data ApplicationDeploymentUpdateRequest) (ApplicationDeploymentUpdateResponse, error) {
+func (c *Client) UpdateApplicationDeployment(ctx context.Context, id string, data ApplicationDeploymentUpdateRequest) (ApplicationDeploymentUpdateResponse, error) {
 	var o ApplicationDeploymentUpdateResponse
 	marshal, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("PUT", fmt.Sprintf("%s/application_deployments/%v", c.ApiURL, id), strings.NewReader(string(marshal)), headers, &o)
+	err = c.RequestAndMap(ctx, "PUT", fmt.Sprintf("%s/application_deployments/%v", c.ApiURL, id), strings.NewReader(string(marshal)), headers, &o)
 	if err != nil {
 		return nil, err
 	}
 	return o, nil
 }
 
-func (c *Client) FindApplicationDeploymentByApplicationAndEnvironment(application string, environment string) (*ApplicationDeploymentFindByApplicationAndEnvironmentResponse, error) {
+func (c *Client) FindApplicationDeploymentByApplicationAndEnvironment(ctx context.Context, application string, environment string) (*ApplicationDeploymentFindByApplicationAndEnvironmentResponse, error) {
 	o := ApplicationDeploymentFindByApplicationAndEnvironmentResponse{}
 
 	err :=
-		c.RequestAndMap("GET", fmt.Sprintf("%s/application_deployments/search/findByApplicationAndEnvironment?application=%v&environment=%v",
-			c.ApiURL, url.QueryEscape(application), url.QueryEscape(environment)), nil, nil, &o)
+		c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/application_deployments/search/findByApplicationAndEnvironment?application=%v&environment=%v",
+			c.ApiURL, url.QueryEscape(application), url.QueryEscape(environment)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) OperateApplicationDeployment(id string, action string, data ApplicationDeploymentOperationRequest) error {
+func (c *Client) OperateApplicationDeployment(ctx context.Context, id string, action string, data ApplicationDeploymentOperationRequest) error {
 	marshal, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("PUT", fmt.Sprintf("%s/application_deployments/%v/operation?action=%s", c.ApiURL, id, action), strings.NewReader(string(marshal)), headers, nil)
+	err = c.RequestAndMap(ctx, "PUT", fmt.Sprintf("%s/application_deployments/%v/operation?action=%s", c.ApiURL, id, action), strings.NewReader(string(marshal)), headers, nil)
 	if err != nil {
+		if apiErr, ok := AsAPIError(err); ok && apiErr.HasMessage("Invalid action for this state of deployment") {
+			return fmt.Errorf("%w: %w", InvalidDeploymentStateError, err)
+		}
 		return err
 	}
 	return nil
 }
 
-func (c *Client) GetApplicationDeploymentStatus(id string) (*ApplicationDeploymentStatusResponse, error) {
+func (c *Client) GetApplicationDeploymentStatus(ctx context.Context, id string) (*ApplicationDeploymentStatusResponse, error) {
 	o := ApplicationDeploymentStatusResponse{}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/application_deployments/%v/status", c.ApiURL, id), nil, headers, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/application_deployments/%v/status", c.ApiURL, id), nil, headers, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
+
+// WaitForApplicationDeploymentOperation waits until the deployment reached the state of the action
+// given to OperateApplicationDeployment: running after START and stopped after STOP. Only the status
+// of the deployment type is checked: the KSML status of a KSML deployment and the connector state otherwise.
+// A failed deployment ends the wait for START with an error once it left the failed state of an earlier run.
+func (c *Client) WaitForApplicationDeploymentOperation(ctx context.Context, id string, action string) error {
+	deployment, err := c.GetApplicationDeployment(ctx, id)
+	if err != nil {
+		return err
+	}
+	ksml := deployment.Embedded.Application.ApplicationType == "Ksml"
+
+	state := "running"
+	if action == "STOP" {
+		state = "stopped"
+	}
+	started := false
+	return c.waitFor(ctx, fmt.Sprintf("application deployment %s to be %s", id, state), func(ctx context.Context) (bool, error) {
+		status, err := c.GetApplicationDeploymentStatus(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		current := status.ConnectorState.State
+		if ksml {
+			current = status.KsmlStatus.Status
+		}
+		if action == "STOP" {
+			return (ksml && current == "Undeployed") || (!ksml && current == "Stopped"), nil
+		}
+		if current != "Failed" {
+			started = true
+		} else if started {
+			return false, fmt.Errorf("application deployment %s failed to start", id)
+		}
+		return current == "Running", nil
+	})
+}
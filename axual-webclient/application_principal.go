@@ -1,59 +1,61 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 )
 
-func (c *Client) ReadApplicationPrincipal(id string) (*ApplicationPrincipalResponse, error) {
+func (c *Client) ReadApplicationPrincipal(ctx context.Context, id string) (*ApplicationPrincipalResponse, error) {
 	o := ApplicationPrincipalResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/application_principals/%s", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/application_principals/%s", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) CreateApplicationPrincipal(applicationPrincipalRequest [1]ApplicationPrincipalRequest) (ApplicationPrincipalCreateResponse, error) {
+func (c *Client) CreateApplicationPrincipal(ctx context.Context, applicationPrincipalRequest [1]ApplicationPrincipalRequest) (ApplicationPrincipalCreateResponse, error) {
 	var o ApplicationPrincipalCreateResponse
 	marshal, err := json.Marshal(applicationPrincipalRequest)
 	if err != nil {
 		return "Error creating payload for application principal", err
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/application_principals", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/application_principals", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
 	if err != nil {
 		return "Error sending POST request for application principal", err
 	}
-	time.Sleep(2 * time.Second) // Principal application can take significant time to apply in Kafka cluster
 	return o, nil
 }
 
-func (c *Client) ActivateApplicationPrincipal(id string) error {
-	err := c.RequestAndMap("POST", fmt.Sprintf("%s/application_authentications/%v/activate", c.ApiURL, id), nil, nil, nil)
+func (c *Client) ActivateApplicationPrincipal(ctx context.Context, id string) error {
+	// A principal that was just created is rejected until the platform applied it to the Kafka cluster,
+	// and activating it twice has the same effect, so the request is retried on conflicts as well.
+	retryCtx, _ := withConflictRetries(ctx)
+	err := c.RequestAndMap(retryCtx, "POST", fmt.Sprintf("%s/application_authentications/%v/activate", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) DeleteApplicationPrincipal(id string) error {
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/application_principals/%v", c.ApiURL, id), nil, nil, nil)
+func (c *Client) DeleteApplicationPrincipal(ctx context.Context, id string) error {
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/application_principals/%v", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) FindApplicationPrincipalByApplicationAndEnvironment(application string, environment string) (*ApplicationPrincipalFindByApplicationAndEnvironmentResponse, error) {
+func (c *Client) FindApplicationPrincipalByApplicationAndEnvironment(ctx context.Context, application string, environment string) (*ApplicationPrincipalFindByApplicationAndEnvironmentResponse, error) {
 	o := ApplicationPrincipalFindByApplicationAndEnvironmentResponse{}
 
 	err :=
-		c.RequestAndMap("GET", fmt.Sprintf("%s/application_principals/search/findByApplicationAndEnvironment?application=%v&environment=%v",
-			c.ApiURL, url.QueryEscape(application), url.QueryEscape(environment)), nil, nil, &o)
+		c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/application_principals/search/findByApplicationAndEnvironment?application=%v&environment=%v",
+			c.ApiURL, url.QueryEscape(application), url.QueryEscape(environment)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
@@ -1,66 +1,76 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
-func (c *Client) GetApplication(id string) (*ApplicationResponse, error) {
+func (c *Client) GetApplication(ctx context.Context, id string) (*ApplicationResponse, error) {
 	o := ApplicationResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/applications/%v", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/applications/%v", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) UpdateApplication(id string, data ApplicationRequest) (*ApplicationResponse, error) {
+// ListApplications returns every application the user can see.
+func (c *Client) ListApplications(ctx context.Context) (*ApplicationsResponse, error) {
+	o := ApplicationsResponse{}
+	err := c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/applications", c.ApiURL), nil, &o)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (c *Client) UpdateApplication(ctx context.Context, id string, data ApplicationRequest) (*ApplicationResponse, error) {
 	o := ApplicationResponse{}
 	marshal, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.RequestAndMap("PATCH", fmt.Sprintf("%s/applications/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
+	err = c.RequestAndMap(ctx, "PATCH", fmt.Sprintf("%s/applications/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) DeleteApplication(id string) error {
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/applications/%v", c.ApiURL, id), nil, nil, nil)
+func (c *Client) DeleteApplication(ctx context.Context, id string) error {
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/applications/%v", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) CreateApplication(data ApplicationRequest) (*ApplicationResponse, error) {
+func (c *Client) CreateApplication(ctx context.Context, data ApplicationRequest) (*ApplicationResponse, error) {
 	o := ApplicationResponse{}
 	marshal, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/applications", c.ApiURL), strings.NewReader(string(marshal)), nil, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/applications", c.ApiURL), strings.NewReader(string(marshal)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) GetApplicationByNameOrShortName(params url.Values) (*ApplicationResponse, error) {
+func (c *Client) GetApplicationByNameOrShortName(ctx context.Context, params url.Values) (*ApplicationResponse, error) {
     o := ApplicationResponse{}
 	endpoint := fmt.Sprintf("findByName?name=%s", params.Get("name")) 
 	if params.Get("shortName") != "" {
     	endpoint = fmt.Sprintf("findByShortName?shortName=%s", params.Get("shortName"))
 	}
     url := fmt.Sprintf("%s/applications/search/%s", c.ApiURL, endpoint)
-	fmt.Println("URL", url)
-    err := c.RequestAndMap("GET", url, nil, nil, &o)
+    err := c.RequestAndMap(ctx, "GET", url, nil, nil, &o)
     if err != nil {
         return nil, err
     }
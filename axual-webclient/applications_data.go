@@ -22,6 +22,12 @@ type ApplicationResponse struct {
 	ApplicationId string `json:"applicationId"`
 }
 
+type ApplicationsResponse struct {
+	Embedded struct {
+		Applications []ApplicationResponse `json:"applications"`
+	} `json:"_embedded"`
+}
+
 type ApplicationRequest struct {
 	ApplicationType  string   `json:"applicationType"`
 	ApplicationId    string   `json:"applicationId"`
@@ -1,24 +1,31 @@
 package webclient
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
-	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// Global variables to cache the token source
-var (
-	tokenSourceCache oauth2.TokenSource
-	tokenSourceMu    sync.Mutex
+// Grant types and token type of the token exchange (RFC 8693) and JWT bearer (RFC 7523) grants.
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	jwtBearerGrantType     = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	jwtTokenType           = "urn:ietf:params:oauth:token-type:jwt"
 )
 
+// tokenFileRefreshInterval is how long a token read from a token file is used when it has no expiry of its own.
+const tokenFileRefreshInterval = time.Minute
+
 // tokenSourceFunc is a helper type that implements oauth2.TokenSource.
 type tokenSourceFunc func() (*oauth2.Token, error)
 
@@ -36,32 +43,76 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-// getCachedTokenSource returns a singleton oauth2.TokenSource for Auth0 authentication.
-func getCachedTokenSource(auth AuthStruct) oauth2.TokenSource {
-	tokenSourceMu.Lock()
-	defer tokenSourceMu.Unlock()
+// SignIn creates an authenticated HTTP client.
+// With the client_credentials grant, the client authenticates as itself for both auth modes. With the
+// token_exchange and jwt_bearer grants, a JWT issued by another identity provider, e.g. the OIDC token of
+// a CI runner, is exchanged for a token of the client.
+// With the password grant, Auth0 requests tokens with an audience and Keycloak uses the normal password grant flow.
+// A static AccessToken or AccessTokenFile is used as is, without a token request.
+// With an Issuer, the token endpoint and auth mode are taken from its OpenID Connect discovery document.
+// Every call creates its own token source, so clients with different credentials never share tokens.
+// The context bounds the initial token request only; token refreshes happen in the background.
+func SignIn(ctx context.Context, auth AuthStruct) (*http.Client, error) {
+	if auth.Issuer != "" && !auth.hasStaticToken() {
+		var err error
+		if auth, err = auth.discover(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return signIn(ctx, auth)
+}
 
-	// Create the token source only once.
-	if tokenSourceCache == nil {
-		// For long-lived sessions, we ensure offline_access is included.
-		if auth.AuthMode == "auth0" && !contains(auth.Scopes, "offline_access") {
-			auth.Scopes = append(auth.Scopes, "offline_access")
+// signIn creates an authenticated HTTP client for auth, after discovery.
+func signIn(ctx context.Context, auth AuthStruct) (*http.Client, error) {
+	if auth.AccessToken != "" {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: auth.AccessToken, TokenType: "Bearer"})
+		return oauth2.NewClient(auth.httpClientContext(), ts), nil
+	}
+	if auth.AccessTokenFile != "" {
+		// Read the file right away, so a missing file fails the sign-in.
+		token, err := readTokenFile(auth.AccessTokenFile)
+		if err != nil {
+			return nil, err
 		}
-		tokenSourceCache = oauth2.ReuseTokenSource(nil, tokenSourceFunc(func() (*oauth2.Token, error) {
-			return getTokenWithAudience(auth)
+		ts := oauth2.ReuseTokenSource(token, tokenSourceFunc(func() (*oauth2.Token, error) {
+			return readTokenFile(auth.AccessTokenFile)
 		}))
+		return oauth2.NewClient(auth.httpClientContext(), ts), nil
+	}
+	if auth.AuthMode != "keycloak" && auth.AuthMode != "auth0" {
+		return nil, fmt.Errorf("invalid auth mode: %s", auth.AuthMode)
+	}
+	switch auth.GrantType {
+	case "", "password":
+		return signInWithPassword(ctx, auth)
+	case "client_credentials", "token_exchange", "jwt_bearer":
+		// Fetch the first token right away, so invalid credentials fail the sign-in.
+		token, err := getClientToken(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		ts := oauth2.ReuseTokenSource(token, tokenSourceFunc(func() (*oauth2.Token, error) {
+			return getClientToken(context.Background(), auth)
+		}))
+		return oauth2.NewClient(auth.httpClientContext(), ts), nil
+	default:
+		return nil, fmt.Errorf("invalid grant type: %s", auth.GrantType)
 	}
-	return tokenSourceCache
 }
 
-// SignIn creates an authenticated HTTP client.
-// For Auth0, it uses the cached token source.
-// For Keycloak, it uses the normal password grant flow.
-func SignIn(auth AuthStruct) (*http.Client, error) {
+// signInWithPassword creates an authenticated HTTP client with the resource owner password grant.
+func signInWithPassword(ctx context.Context, auth AuthStruct) (*http.Client, error) {
 	switch auth.AuthMode {
 	case "auth0":
-		ts := getCachedTokenSource(auth)
-		return oauth2.NewClient(context.Background(), ts), nil
+		// Fetch the first token right away, so invalid credentials fail the sign-in.
+		token, err := getTokenWithAudience(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		ts := oauth2.ReuseTokenSource(token, tokenSourceFunc(func() (*oauth2.Token, error) {
+			return getTokenWithAudience(context.Background(), auth)
+		}))
+		return oauth2.NewClient(auth.httpClientContext(), ts), nil
 	case "keycloak":
 		userName := auth.Username
 		password := auth.Password
@@ -75,12 +126,12 @@ func SignIn(auth AuthStruct) (*http.Client, error) {
 		if auth.Scopes != nil {
 			conf.Scopes = auth.Scopes
 		}
-		token, err := conf.PasswordCredentialsToken(context.Background(), userName, password)
+		token, err := conf.PasswordCredentialsToken(context.WithValue(ctx, oauth2.HTTPClient, auth.httpClient()), userName, password)
 		if err != nil {
 			return nil, err
 		}
-		ts := oauth2.ReuseTokenSource(token, conf.TokenSource(context.Background(), token))
-		return oauth2.NewClient(context.Background(), ts), nil
+		ts := oauth2.ReuseTokenSource(token, conf.TokenSource(auth.httpClientContext(), token))
+		return oauth2.NewClient(auth.httpClientContext(), ts), nil
 	default:
 		return nil, fmt.Errorf("invalid auth mode: %s", auth.AuthMode)
 	}
@@ -88,7 +139,7 @@ func SignIn(auth AuthStruct) (*http.Client, error) {
 
 // getTokenWithAudience fetches an access token from Auth0.
 // It adds the offline_access scope if missing to ensure a refresh token is issued.
-func getTokenWithAudience(auth AuthStruct) (*oauth2.Token, error) {
+func getTokenWithAudience(ctx context.Context, auth AuthStruct) (*oauth2.Token, error) {
 	// Ensure offline_access is requested for Auth0.
 	if auth.AuthMode == "auth0" && !contains(auth.Scopes, "offline_access") {
 		auth.Scopes = append(auth.Scopes, "offline_access")
@@ -104,27 +155,96 @@ func getTokenWithAudience(auth AuthStruct) (*oauth2.Token, error) {
 	if len(auth.Scopes) > 0 {
 		data.Set("scope", strings.Join(auth.Scopes, " "))
 	}
+	return requestToken(ctx, auth, data)
+}
 
-	req, err := http.NewRequest("POST", auth.Url, strings.NewReader(data.Encode()))
+// getClientToken fetches an access token with the client_credentials, token_exchange or jwt_bearer grant. The
+// client authenticates with its secret, or with a signed JWT assertion (private_key_jwt) when a private key is configured.
+func getClientToken(ctx context.Context, auth AuthStruct) (*oauth2.Token, error) {
+	data := url.Values{}
+	switch auth.GrantType {
+	case "client_credentials":
+		data.Set("grant_type", "client_credentials")
+	case "token_exchange", "jwt_bearer":
+		// The subject token is read for every request, as short-lived tokens are rotated by the issuer.
+		subjectToken, err := auth.subjectToken()
+		if err != nil {
+			return nil, err
+		}
+		if auth.GrantType == "token_exchange" {
+			data.Set("grant_type", tokenExchangeGrantType)
+			data.Set("subject_token", subjectToken)
+			data.Set("subject_token_type", firstNonEmpty(auth.SubjectTokenType, jwtTokenType))
+		} else {
+			data.Set("grant_type", jwtBearerGrantType)
+			data.Set("assertion", subjectToken)
+		}
+	default:
+		return nil, fmt.Errorf("invalid grant type: %s", auth.GrantType)
+	}
+	if err := setClientAuthentication(data, auth); err != nil {
+		return nil, err
+	}
+	if auth.Audience != "" {
+		data.Set("audience", auth.Audience)
+	}
+	if len(auth.Scopes) > 0 {
+		data.Set("scope", strings.Join(auth.Scopes, " "))
+	}
+	return requestToken(ctx, auth, data)
+}
+
+// setClientAuthentication adds the client credentials to a token request, using the client_secret_post
+// or the private_key_jwt client authentication method.
+func setClientAuthentication(data url.Values, auth AuthStruct) error {
+	data.Set("client_id", auth.ClientId)
+	if auth.PrivateKeyPEM != "" || auth.PrivateKeyFile != "" {
+		assertion, err := newClientAssertion(auth)
+		if err != nil {
+			return err
+		}
+		data.Set("client_assertion_type", clientAssertionType)
+		data.Set("client_assertion", assertion)
+		return nil
+	}
+	if auth.ClientSecret != "" {
+		data.Set("client_secret", auth.ClientSecret)
+	}
+	return nil
+}
+
+// requestToken posts a token request to the token endpoint and parses the token response.
+func requestToken(ctx context.Context, auth AuthStruct, data url.Values) (*oauth2.Token, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", auth.Url, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	ctx = logContext(ctx)
+	fields := map[string]interface{}{
+		"url":        auth.Url,
+		"grant_type": data.Get("grant_type"),
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Requesting token", fields)
+	start := time.Now()
+	resp, err := auth.httpClient().Do(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
 	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Network error during token request", fields, map[string]interface{}{"error": err.Error()})
 		return nil, err
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
-			fmt.Printf("warning: failed to close response body: %v\n", closeErr)
+			tflog.SubsystemWarn(ctx, logSubsystem, "Failed to close response body", map[string]interface{}{"error": closeErr.Error()})
 		}
 	}()
+	fields["status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received token response", fields)
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get token, status: %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("failed to get token, status: %d, body: %s", resp.StatusCode, redactBody(bodyBytes))
 	}
 
 	var tokenResp struct {
@@ -143,8 +263,87 @@ func getTokenWithAudience(auth AuthStruct) (*oauth2.Token, error) {
 	token := &oauth2.Token{
 		AccessToken:  tokenResp.AccessToken,
 		TokenType:    tokenResp.TokenType,
-		Expiry:       time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
 		RefreshToken: tokenResp.RefreshToken,
 	}
+	// A token without expires_in does not expire.
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
 	return token, nil
 }
+
+// hasStaticToken returns whether auth uses a bearer token acquired outside of the client.
+func (auth AuthStruct) hasStaticToken() bool {
+	return auth.AccessToken != "" || auth.AccessTokenFile != ""
+}
+
+// readTokenFile reads a bearer token from a file. The token expires with the exp claim when it is a JWT,
+// or after tokenFileRefreshInterval otherwise, after which the file is read again.
+func readTokenFile(file string) (*oauth2.Token, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token file: %w", err)
+	}
+	accessToken := strings.TrimSpace(string(data))
+	if accessToken == "" {
+		return nil, fmt.Errorf("token file %s is empty", file)
+	}
+	expiry, ok := jwtExpiry(accessToken)
+	if !ok {
+		expiry = time.Now().Add(tokenFileRefreshInterval)
+	}
+	return &oauth2.Token{AccessToken: accessToken, TokenType: "Bearer", Expiry: expiry}, nil
+}
+
+// jwtExpiry returns the exp claim of a JWT, without verifying it.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// subjectToken returns the token to exchange, read from SubjectTokenFile or else from the SubjectTokenEnv environment variable.
+func (auth AuthStruct) subjectToken() (string, error) {
+	var token string
+	if auth.SubjectTokenFile != "" {
+		data, err := os.ReadFile(auth.SubjectTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read subject token file: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	} else if auth.SubjectTokenEnv != "" {
+		token = strings.TrimSpace(os.Getenv(auth.SubjectTokenEnv))
+	} else {
+		return "", fmt.Errorf("the %s grant requires a subject token file or environment variable", auth.GrantType)
+	}
+	if token == "" {
+		return "", fmt.Errorf("the subject token for the %s grant is empty", auth.GrantType)
+	}
+	return token, nil
+}
+
+// httpClient returns the HTTP client for the token requests.
+func (auth AuthStruct) httpClient() *http.Client {
+	if auth.HTTPClient != nil {
+		return auth.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// httpClientContext returns a long-lived context that makes oauth2 use the HTTP client of auth,
+// both for token refreshes and as the base transport of the authenticated client.
+func (auth AuthStruct) httpClientContext() context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, auth.httpClient())
+}
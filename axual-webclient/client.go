@@ -1,14 +1,15 @@
 package webclient
 
 import (
-	"crypto/tls"
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Client represents an HTTP client configured to communicate with the API.
@@ -17,6 +18,40 @@ type Client struct {
 	ApiURL     string
 	Realm      string
 	AuthMode   string
+	// RetryPolicy decides which failed requests are retried and how long to wait in between.
+	RetryPolicy RetryPolicy
+	// PollingPolicy decides how often and how long to check that changes are applied by the platform.
+	PollingPolicy PollingPolicy
+
+	tlsConfig      TLSConfig
+	requestTimeout time.Duration
+	limiter        *limiter
+	platform       *PlatformInfo
+	proxyConfig    ProxyConfig
+	headers        map[string]string
+	recorder       *Recorder
+}
+
+// DefaultRequestTimeout bounds a single HTTP request unless WithRequestTimeout is given.
+// The context of a call bounds the call as a whole, including its retries.
+const DefaultRequestTimeout = 30 * time.Second
+
+// Option configures optional Client settings in NewClient.
+type Option func(*Client)
+
+// WithRetryPolicy replaces the DefaultRetryPolicy of the Client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithRequestTimeout replaces the DefaultRequestTimeout of the API and token requests of the Client.
+// Zero only bounds requests by their context.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
 }
 
 // AuthStruct holds the authentication configuration.
@@ -28,31 +63,95 @@ type AuthStruct struct {
 	Scopes   []string
 	Audience string
 	AuthMode string // "keycloak" or "auth0"
+	// Issuer is the OpenID Connect issuer URL. When set, Url and AuthMode default to the token endpoint
+	// of its discovery document and the kind of issuer, and the configuration is checked against it.
+	Issuer string
+	// GrantType is "password" (the default), "client_credentials", "token_exchange" (RFC 8693) or "jwt_bearer" (RFC 7523).
+	GrantType string
+	// SubjectTokenFile or SubjectTokenEnv, the name of an environment variable, hold the JWT exchanged with the
+	// token_exchange and jwt_bearer grants. SubjectTokenType defaults to urn:ietf:params:oauth:token-type:jwt.
+	SubjectTokenFile string
+	SubjectTokenEnv  string
+	SubjectTokenType string
+	// ClientSecret authenticates the client with the client_credentials grant.
+	ClientSecret string
+	// PrivateKeyPEM or PrivateKeyFile hold an RSA or ECDSA private key. When set, the client authenticates
+	// with a signed JWT assertion (private_key_jwt) instead of a secret; PrivateKeyID is sent as the "kid" header.
+	PrivateKeyPEM  string
+	PrivateKeyFile string
+	PrivateKeyID   string
+	// AccessToken is a static bearer token, used instead of requesting tokens. AccessTokenFile holds a
+	// bearer token that is read again when it expires, e.g. a token that is rotated by a sidecar.
+	AccessToken     string
+	AccessTokenFile string
+	// HTTPClient is used for the token requests; http.DefaultClient is used when nil.
+	// When nil, NewClient sets it to a client sharing the transport of the API requests.
+	HTTPClient *http.Client
 }
 
-var NotFoundError = errors.New("resource not found")
-var UnprocessableEntityError = errors.New("unprocessable entity")
-
 // NewClient creates a new Client using the provided API URL, realm, and authentication settings.
-func NewClient(apiUrl string, realm string, auth AuthStruct) (*Client, error) {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	// SignIn will choose the appropriate token flow (Keycloak or Auth0) based on auth.AuthMode.
-	client, err := SignIn(auth)
+// The context is only used for the initial sign-in; every API call takes its own context.
+func NewClient(ctx context.Context, apiUrl string, realm string, auth AuthStruct, options ...Option) (*Client, error) {
+	c := Client{
+		ApiURL:         apiUrl,
+		Realm:          realm,
+		AuthMode:       auth.AuthMode,
+		RetryPolicy:    DefaultRetryPolicy(),
+		PollingPolicy:  DefaultPollingPolicy(),
+		requestTimeout: DefaultRequestTimeout,
+	}
+	for _, option := range options {
+		option(&c)
+	}
+
+	// The transport is owned by the Client, so its TLS and proxy settings never leak into other HTTP clients of the process.
+	tlsConfig, err := c.tlsConfig.build()
 	if err != nil {
 		return nil, err
 	}
-	client.Timeout = 30 * time.Second
-	c := Client{
-		HTTPClient: client,
-		ApiURL:     apiUrl,
-		Realm:      realm,
-		AuthMode:   auth.AuthMode,
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if proxy := c.proxyConfig.proxy(); proxy != nil {
+		transport.Proxy = proxy
+	}
+	var tokenTransport http.RoundTripper = transport
+	if c.recorder != nil {
+		tokenTransport = c.recorder.transport(transport)
+	}
+	if len(c.headers) > 0 {
+		tokenTransport = &headerTransport{base: tokenTransport, headers: c.headers}
+	}
+	if auth.HTTPClient == nil {
+		auth.HTTPClient = &http.Client{Transport: tokenTransport, Timeout: c.requestTimeout}
+	}
+
+	// The auth mode of an issuer decides on the realm header. It is taken from discovery, or from the issuer
+	// URL when a static token makes discovery unnecessary.
+	if auth.Issuer != "" {
+		if !auth.hasStaticToken() {
+			if auth, err = auth.discover(ctx); err != nil {
+				return nil, err
+			}
+		} else if auth.AuthMode == "" {
+			auth.AuthMode = issuerAuthMode(auth.Issuer)
+		}
+		c.AuthMode = auth.AuthMode
 	}
+
+	// signIn will choose the appropriate token flow (Keycloak or Auth0) based on auth.AuthMode.
+	client, err := signIn(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = c.requestTimeout
+	c.HTTPClient = client
 	return &c, nil
 }
 
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	log.Println("Executing HTTP request...")
+	ctx := req.Context()
 	// Only set Realm header if AuthMode is keycloak.
 	if c.AuthMode == "keycloak" {
 		req.Header.Set("Realm", c.Realm)
@@ -64,61 +163,121 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 	if req.Header.Get("Content-Type") == "" {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	setHeaders(req.Header, c.headers)
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+	start := time.Now()
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if wait := time.Since(start); wait >= time.Millisecond {
+		fields["wait_ms"] = wait.Milliseconds()
+	}
 
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending request", fields)
+	start = time.Now()
 	res, err := c.HTTPClient.Do(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
 	if err != nil {
-		log.Printf("Network error during HTTP request: %v", err)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Network error during request", fields, map[string]interface{}{"error": err.Error()})
 		return nil, err
 	}
 	if res == nil {
 		return nil, fmt.Errorf("received nil response from HTTP client")
 	}
-	if res.StatusCode == http.StatusNotFound {
-		return nil, NotFoundError
-	}
-	if res.StatusCode == http.StatusUnprocessableEntity {
-		return nil, UnprocessableEntityError
-	}
 	defer func() {
 		if closeErr := res.Body.Close(); closeErr != nil {
-			fmt.Printf("warning: failed to close response body: %v\n", closeErr)
+			tflog.SubsystemWarn(ctx, logSubsystem, "Failed to close response body", map[string]interface{}{"error": closeErr.Error()})
 		}
 	}()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		log.Printf("Error reading response body: %v", err)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Error reading response body", fields, map[string]interface{}{"error": err.Error()})
 		return nil, err
 	}
 
+	fields["status"] = res.StatusCode
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received response", fields)
+	tflog.SubsystemTrace(ctx, logSubsystem, "Response body", map[string]interface{}{"body": redactBody(body)})
+
 	if res.StatusCode != http.StatusOK &&
 		res.StatusCode != http.StatusNoContent &&
 		res.StatusCode != http.StatusCreated {
-		log.Printf("Unexpected response status: %d, body: %s", res.StatusCode, body)
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, newAPIError(req, res, body)
 	}
 
 	return body, err
 }
 
-func (c *Client) RequestAndMap(method string, url string, reqBody io.Reader, header map[string]string, m interface{}) error {
-	req, err := http.NewRequest(method, url, reqBody)
+// RequestAndMap sends a request and unmarshals the response body into m.
+// Requests failing with a transient error are retried according to the RetryPolicy of the Client.
+// Every call is logged with its own request ID, which is also sent to the API in the X-Request-Id header.
+func (c *Client) RequestAndMap(ctx context.Context, method string, url string, reqBody io.Reader, header map[string]string, m interface{}) error {
+	requestID := newRequestID()
+	ctx = tflog.SubsystemSetField(logContext(ctx), logSubsystem, "request_id", requestID)
 
-	if err != nil {
-		log.Printf("Error creating HTTP request: %v", err)
-		return err
+	// The body is buffered so it can be sent again when the request is retried.
+	var payload []byte
+	if reqBody != nil {
+		var err error
+		payload, err = io.ReadAll(reqBody)
+		if err != nil {
+			tflog.SubsystemError(ctx, logSubsystem, "Error reading request body", map[string]interface{}{"error": err.Error()})
+			return err
+		}
+		tflog.SubsystemTrace(ctx, logSubsystem, "Request body", map[string]interface{}{"body": redactBody(payload)})
 	}
 
-	if header != nil {
+	var body []byte
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if payload != nil {
+			bodyReader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			tflog.SubsystemError(ctx, logSubsystem, "Error creating request", map[string]interface{}{"error": err.Error()})
+			return err
+		}
+
 		for key, value := range header {
 			req.Header.Set(key, value)
 		}
-	}
+		req.Header.Set(requestIDHeader, requestID)
 
-	body, err := c.doRequest(req)
-	if err != nil {
-		log.Printf("Error performing HTTP request: %v", err)
-		return err
+		body, err = c.doRequest(req)
+		if err == nil {
+			break
+		}
+
+		conflictRetries := conflictRetriesOf(ctx)
+		delay, retry := c.RetryPolicy.retryDelay(method, conflictRetries != nil, attempt, err)
+		if !retry {
+			tflog.SubsystemDebug(ctx, logSubsystem, "Request failed", map[string]interface{}{
+				"method": method,
+				"url":    url,
+				"error":  err.Error(),
+			})
+			return err
+		}
+		tflog.SubsystemWarn(ctx, logSubsystem, "Retrying request", map[string]interface{}{
+			"method":      method,
+			"url":         url,
+			"delay":       delay.String(),
+			"retry":       attempt + 1,
+			"max_retries": c.RetryPolicy.MaxRetries,
+			"error":       err.Error(),
+		})
+		conflictRetries.retried(err)
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			return err
+		}
 	}
 
 	if m != nil {
@@ -126,12 +285,24 @@ func (c *Client) RequestAndMap(method string, url string, reqBody io.Reader, hea
 			return nil
 		}
 
-		err = json.Unmarshal(body, &m)
-		if err != nil {
-			log.Printf("Error unmarshaling response body: %v", err)
+		if err := json.Unmarshal(body, &m); err != nil {
+			tflog.SubsystemError(ctx, logSubsystem, "Error unmarshaling response body", map[string]interface{}{"error": err.Error()})
 			return err
 		}
 	}
 
 	return nil
 }
+
+// sleepContext pauses for the given duration, returning early with the context error
+// when the context is cancelled or its deadline passes.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,151 @@
+package webclient
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// clientAssertionType is the client_assertion_type of private_key_jwt client authentication (RFC 7523).
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// clientAssertionLifetime is how long a signed client assertion is valid. Every token request signs a new one.
+const clientAssertionLifetime = 5 * time.Minute
+
+// newClientAssertion signs the JWT used for private_key_jwt client authentication. The client is
+// both issuer and subject, and the audience is the token endpoint.
+func newClientAssertion(auth AuthStruct) (string, error) {
+	keyPEM, err := pemContent("client assertion private key", auth.PrivateKeyPEM, auth.PrivateKeyFile)
+	if err != nil {
+		return "", err
+	}
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss": auth.ClientId,
+		"sub": auth.ClientId,
+		"aud": auth.Url,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	}
+	return signJWT(key, auth.PrivateKeyID, claims)
+}
+
+// parsePrivateKey parses a PEM encoded RSA or ECDSA private key in PKCS #8, PKCS #1 or SEC 1 form.
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found for the client assertion")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return k, nil
+		case *ecdsa.PrivateKey:
+			return k, nil
+		default:
+			return nil, fmt.Errorf("unsupported client assertion key type %T, use an RSA or ECDSA key", key)
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unable to parse the client assertion private key, expected an RSA or ECDSA key")
+}
+
+// signJWT creates a compact JWS of the claims, using RS256 for RSA keys and ES256/ES384/ES512 for ECDSA keys.
+func signJWT(key crypto.Signer, keyID string, claims map[string]interface{}) (string, error) {
+	var alg string
+	var hash crypto.Hash
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		alg, hash = "RS256", crypto.SHA256
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			alg, hash = "ES256", crypto.SHA256
+		case elliptic.P384():
+			alg, hash = "ES384", crypto.SHA384
+		case elliptic.P521():
+			alg, hash = "ES512", crypto.SHA512
+		default:
+			return "", fmt.Errorf("unsupported ECDSA curve %s for the client assertion", k.Curve.Params().Name)
+		}
+	}
+
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if keyID != "" {
+		header["kid"] = keyID
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := hashOf(hash, []byte(signingInput))
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		if err != nil {
+			return "", err
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return "", err
+		}
+		// JWS uses the fixed size concatenation of r and s instead of ASN.1.
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = append(padded(r, size), padded(s, size)...)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func hashOf(hash crypto.Hash, data []byte) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384(data)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	default:
+		sum := sha256.Sum256(data)
+		return sum[:]
+	}
+}
+
+func padded(n *big.Int, size int) []byte {
+	out := make([]byte, size)
+	return n.FillBytes(out)
+}
@@ -0,0 +1,118 @@
+package webclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OpenIDConfiguration holds the fields of the OpenID Connect discovery document used to sign in.
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// DiscoveryError is returned when the OpenID Connect discovery document of an issuer cannot be
+// fetched, or does not support the configured authentication.
+type DiscoveryError struct {
+	Issuer string
+	Err    error
+}
+
+func (e *DiscoveryError) Error() string {
+	return fmt.Sprintf("OIDC discovery for issuer %s failed: %s", e.Issuer, e.Err)
+}
+
+func (e *DiscoveryError) Unwrap() error {
+	return e.Err
+}
+
+// grantTypeURIs maps the grant types of AuthStruct to the values listed in grant_types_supported.
+var grantTypeURIs = map[string]string{
+	"":                   "password",
+	"password":           "password",
+	"client_credentials": "client_credentials",
+	"token_exchange":     tokenExchangeGrantType,
+	"jwt_bearer":         jwtBearerGrantType,
+}
+
+// DiscoverOpenIDConfiguration fetches the discovery document from the .well-known/openid-configuration
+// endpoint of the issuer, and checks that it belongs to the issuer.
+func DiscoverOpenIDConfiguration(ctx context.Context, httpClient *http.Client, issuer string) (*OpenIDConfiguration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, &DiscoveryError{Issuer: issuer, Err: err}
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, &DiscoveryError{Issuer: issuer, Err: err}
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, &DiscoveryError{Issuer: issuer, Err: err}
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &DiscoveryError{Issuer: issuer, Err: fmt.Errorf("status: %d, body: %s", res.StatusCode, body)}
+	}
+	var config OpenIDConfiguration
+	if err := json.Unmarshal(body, &config); err != nil {
+		return nil, &DiscoveryError{Issuer: issuer, Err: fmt.Errorf("invalid discovery document: %w", err)}
+	}
+	if strings.TrimSuffix(config.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, &DiscoveryError{Issuer: issuer, Err: fmt.Errorf("discovery document is for issuer %q", config.Issuer)}
+	}
+	if config.TokenEndpoint == "" {
+		return nil, &DiscoveryError{Issuer: issuer, Err: fmt.Errorf("discovery document has no token_endpoint")}
+	}
+	return &config, nil
+}
+
+// discover completes auth with the token endpoint and auth mode of the issuer, and checks that the issuer
+// supports the grant type and client authentication. Settings that are already configured are kept.
+func (auth AuthStruct) discover(ctx context.Context) (AuthStruct, error) {
+	config, err := DiscoverOpenIDConfiguration(ctx, auth.httpClient(), auth.Issuer)
+	if err != nil {
+		return auth, err
+	}
+
+	grantType := grantTypeURIs[auth.GrantType]
+	if len(config.GrantTypesSupported) > 0 && grantType != "" && !contains(config.GrantTypesSupported, grantType) {
+		return auth, &DiscoveryError{Issuer: auth.Issuer, Err: fmt.Errorf("grant type %s is not supported, supported grant types are %s",
+			grantType, strings.Join(config.GrantTypesSupported, ", "))}
+	}
+	authMethod := ""
+	if auth.PrivateKeyPEM != "" || auth.PrivateKeyFile != "" {
+		authMethod = "private_key_jwt"
+	} else if auth.ClientSecret != "" {
+		authMethod = "client_secret_post"
+	}
+	if len(config.TokenEndpointAuthMethodsSupported) > 0 && authMethod != "" && !contains(config.TokenEndpointAuthMethodsSupported, authMethod) {
+		return auth, &DiscoveryError{Issuer: auth.Issuer, Err: fmt.Errorf("client authentication %s is not supported, supported methods are %s",
+			authMethod, strings.Join(config.TokenEndpointAuthMethodsSupported, ", "))}
+	}
+
+	if auth.Url == "" {
+		auth.Url = config.TokenEndpoint
+	}
+	if auth.AuthMode == "" {
+		auth.AuthMode = issuerAuthMode(config.Issuer)
+	}
+	return auth, nil
+}
+
+// issuerAuthMode returns the auth mode of an issuer URL: Keycloak issuers are the URL of a realm,
+// anything else is treated as Auth0.
+func issuerAuthMode(issuer string) string {
+	if strings.Contains(issuer, "/realms/") {
+		return "keycloak"
+	}
+	return "auth0"
+}
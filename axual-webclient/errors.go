@@ -0,0 +1,210 @@
+package webclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var NotFoundError = errors.New("resource not found")
+var UnprocessableEntityError = errors.New("unprocessable entity")
+var ConflictError = errors.New("conflict")
+var ForbiddenError = errors.New("forbidden")
+var UnauthorizedError = errors.New("unauthorized")
+
+// APIError is returned for every response with an unexpected HTTP status.
+// It matches the sentinel errors above with errors.Is, so callers can keep using
+// errors.Is(err, NotFoundError) while inspecting the details with errors.As.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Body is the raw response body, kept for errors the API did not describe as JSON.
+	Body []byte
+	// Title, Message and Code are parsed from a JSON error body (Spring error, problem details or Spring Data REST).
+	Title   string
+	Message string
+	Code    string
+	// FieldErrors holds the per-field validation errors reported by the API.
+	FieldErrors []FieldError
+	// RetryAfter is the delay requested by the API with a Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+// FieldError is a validation error the API reported for a single request field.
+type FieldError struct {
+	Field         string
+	Message       string
+	RejectedValue interface{}
+}
+
+// errorBody covers the error shapes returned by the self-service API: Spring Boot errors
+// ("error", "message"), RFC 7807 problem details ("title", "detail", "invalid-params")
+// and Spring Data REST validation errors ("errors" with "property"/"field").
+type errorBody struct {
+	Title         string             `json:"title"`
+	Detail        string             `json:"detail"`
+	Error         string             `json:"error"`
+	Message       string             `json:"message"`
+	Code          string             `json:"code"`
+	Errors        errorBodyFields    `json:"errors"`
+	FieldErrors   errorBodyFields    `json:"fieldErrors"`
+	InvalidParams errorBodyFields    `json:"invalid-params"`
+	Violations    errorBodyFields    `json:"violations"`
+	Embedded      *errorBodyEmbedded `json:"_embedded"`
+}
+
+type errorBodyEmbedded struct {
+	Errors errorBodyFields `json:"errors"`
+}
+
+// errorBodyFields ignores field error lists it cannot decode, so an unexpected shape
+// never hides the message of the error itself.
+type errorBodyFields []errorBodyField
+
+func (f *errorBodyFields) UnmarshalJSON(data []byte) error {
+	var fields []errorBodyField
+	if json.Unmarshal(data, &fields) == nil {
+		*f = fields
+	}
+	return nil
+}
+
+type errorBodyField struct {
+	Property      string      `json:"property"`
+	Field         string      `json:"field"`
+	Name          string      `json:"name"`
+	Message       string      `json:"message"`
+	Reason        string      `json:"reason"`
+	DefaultMsg    string      `json:"defaultMessage"`
+	InvalidValue  interface{} `json:"invalidValue"`
+	RejectedValue interface{} `json:"rejectedValue"`
+}
+
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Body:       body,
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+	}
+
+	var parsed errorBody
+	if len(body) == 0 || json.Unmarshal(body, &parsed) != nil {
+		return e
+	}
+	e.Title = firstNonEmpty(parsed.Title, parsed.Error)
+	e.Message = firstNonEmpty(parsed.Detail, parsed.Message)
+	e.Code = parsed.Code
+
+	var fields []errorBodyField
+	fields = append(fields, parsed.Errors...)
+	fields = append(fields, parsed.FieldErrors...)
+	fields = append(fields, parsed.InvalidParams...)
+	fields = append(fields, parsed.Violations...)
+	if parsed.Embedded != nil {
+		fields = append(fields, parsed.Embedded.Errors...)
+	}
+	for _, f := range fields {
+		fe := FieldError{
+			Field:         firstNonEmpty(f.Property, f.Field, f.Name),
+			Message:       firstNonEmpty(f.Message, f.Reason, f.DefaultMsg),
+			RejectedValue: f.InvalidValue,
+		}
+		if fe.RejectedValue == nil {
+			fe.RejectedValue = f.RejectedValue
+		}
+		if fe.Field == "" && fe.Message == "" {
+			continue
+		}
+		e.FieldErrors = append(e.FieldErrors, fe)
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: status: %d", e.Method, e.URL, e.StatusCode)
+	switch {
+	case e.Message != "":
+		fmt.Fprintf(&b, ", message: %s", e.Message)
+	case e.Title != "":
+		fmt.Fprintf(&b, ", message: %s", e.Title)
+	case len(e.FieldErrors) == 0 && len(e.Body) > 0:
+		fmt.Fprintf(&b, ", body: %s", redactBody(e.Body))
+	}
+	for _, fe := range e.FieldErrors {
+		if fe.Field != "" {
+			fmt.Fprintf(&b, "\n  %s: %s", fe.Field, fe.Message)
+		} else {
+			fmt.Fprintf(&b, "\n  %s", fe.Message)
+		}
+	}
+	return b.String()
+}
+
+// Is reports whether the status code of the error matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case NotFoundError:
+		return e.IsNotFound()
+	case UnprocessableEntityError:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ConflictError:
+		return e.IsConflict()
+	case ForbiddenError:
+		return e.IsForbidden()
+	case UnauthorizedError:
+		return e.IsUnauthorized()
+	}
+	return false
+}
+
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *APIError) IsConflict() bool {
+	return e.StatusCode == http.StatusConflict
+}
+
+func (e *APIError) IsForbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsValidation reports whether the API rejected the request content (400 or 422).
+func (e *APIError) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// HasMessage reports whether the API error message, title or body contains the given text.
+// It is meant for the few API responses that can only be told apart by their message.
+func (e *APIError) HasMessage(text string) bool {
+	return strings.Contains(e.Message, text) || strings.Contains(e.Title, text) || strings.Contains(string(e.Body), text)
+}
+
+// AsAPIError returns the APIError wrapped in err, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,8 +1,20 @@
 module axual-webclient
 
-go 1.23.0
+go 1.24.0
 
 require (
+	github.com/hashicorp/terraform-plugin-log v0.10.0
 	golang.org/x/net v0.34.0
 	golang.org/x/oauth2 v0.25.0
+	golang.org/x/time v0.9.0
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/hashicorp/go-hclog v1.6.3 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/mitchellh/go-testing-interface v1.14.1 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
 )
@@ -17,6 +17,12 @@ type GroupResponse struct {
 	} `json:"_embedded"`
 }
 
+type GroupsResponse struct {
+	Embedded struct {
+		Groups []GroupResponse `json:"groups"`
+	} `json:"_embedded"`
+}
+
 type GroupRequest struct {
 	Name         string      `json:"name,omitempty"`
 	EmailAddress interface{} `json:"emailAddress"`
@@ -1,24 +1,44 @@
 package webclient
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
 
-func (c *Client) GetInstanceByName(name string) (*InstanceResponse, error) {
+func (c *Client) GetInstanceByName(ctx context.Context, name string) (*InstanceResponse, error) {
+	if !c.Supports(FeatureInstanceSearchByName) {
+		return c.findInstanceByAttributes(ctx, "name", name)
+	}
 	o := InstanceResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/instances/search/findByName?name=%s", c.ApiURL, url.QueryEscape(name)), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/instances/search/findByName?name=%s", c.ApiURL, url.QueryEscape(name)), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) GetInstanceByShortName(shortName string) (*InstanceResponse, error) {
+func (c *Client) GetInstanceByShortName(ctx context.Context, shortName string) (*InstanceResponse, error) {
+	if !c.Supports(FeatureInstanceSearchByName) {
+		return c.findInstanceByAttributes(ctx, "shortName", shortName)
+	}
 	o := InstanceResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/instances/search/findByShortName?shortName=%s", c.ApiURL, url.QueryEscape(shortName)), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/instances/search/findByShortName?shortName=%s", c.ApiURL, url.QueryEscape(shortName)), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
+
+// findInstanceByAttributes searches an instance on platforms without the findByName and findByShortName searches.
+func (c *Client) findInstanceByAttributes(ctx context.Context, attribute string, value string) (*InstanceResponse, error) {
+	o := InstancesResponse{}
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/instances/search/findByAttributes?%s=%s", c.ApiURL, attribute, url.QueryEscape(value)), nil, nil, &o)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.Embedded.Instances) == 0 {
+		return nil, NotFoundError
+	}
+	return &o.Embedded.Instances[0], nil
+}
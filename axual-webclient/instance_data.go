@@ -6,3 +6,9 @@ type InstanceResponse struct {
 	Description string `json:"description"`
 	Uid         string `json:"uid"`
 }
+
+type InstancesResponse struct {
+	Embedded struct {
+		Instances []InstanceResponse `json:"instances"`
+	} `json:"_embedded"`
+}
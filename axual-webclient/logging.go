@@ -0,0 +1,89 @@
+package webclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the terraform-plugin-log subsystem of the webclient. Its level is set with the
+// TF_LOG_PROVIDER_AXUAL_WEBCLIENT environment variable and defaults to the level of the provider.
+const logSubsystem = "webclient"
+
+// requestIDHeader carries the ID of a request, so it can be correlated with the logs of the API.
+const requestIDHeader = "X-Request-Id"
+
+// redacted replaces the values of sensitive fields in logged bodies.
+const redacted = "***"
+
+// sensitiveFields are the JSON fields, compared case-insensitively, whose values are never logged.
+// The values of configs are redacted as a whole, as connector configs hold passwords.
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"privatekey":    true,
+	"secret":        true,
+	"clientsecret":  true,
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"configs":       true,
+	"configvalue":   true,
+}
+
+// logContext returns the context with the webclient log subsystem, masking the fields that hold credentials.
+func logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_AXUAL", "WEBCLIENT"))
+	return tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, "authorization", "token", "password")
+}
+
+// newRequestID returns a random ID for a request.
+func newRequestID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// privateKeyPattern matches PEM encoded private keys in bodies that are not JSON.
+var privateKeyPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+
+// redactBody returns the body for logging and error messages, with the values of sensitive JSON fields
+// replaced. From bodies that are not JSON, only PEM encoded private keys are removed.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return privateKeyPattern.ReplaceAllString(string(body), redacted)
+	}
+	out, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return string(out)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+	}
+	return value
+}
@@ -0,0 +1,149 @@
+package webclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageMetadata is the page section of a paged HAL collection.
+type PageMetadata struct {
+	Size          int `json:"size"`
+	TotalElements int `json:"totalElements"`
+	TotalPages    int `json:"totalPages"`
+	Number        int `json:"number"`
+}
+
+// Page is a single page of a HAL collection. Embedded holds the `_embedded` arrays by their relation name.
+type Page struct {
+	Embedded map[string]json.RawMessage `json:"_embedded"`
+	Links    struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"_links"`
+	Page *PageMetadata `json:"page"`
+
+	url  string
+	body []byte
+}
+
+// nextURL returns the URL of the next page, from the next link or else from the page metadata,
+// or an empty string for the last page.
+func (p *Page) nextURL() (string, error) {
+	current, err := url.Parse(p.url)
+	if err != nil {
+		return "", err
+	}
+	if p.Links.Next != nil && p.Links.Next.Href != "" {
+		// Strip URI template variables such as {&sort}.
+		href, _, _ := strings.Cut(p.Links.Next.Href, "{")
+		next, err := current.Parse(href)
+		if err != nil {
+			return "", fmt.Errorf("invalid next link %q: %w", p.Links.Next.Href, err)
+		}
+		return next.String(), nil
+	}
+	if p.Page != nil && p.Page.Number+1 < p.Page.TotalPages {
+		query := current.Query()
+		query.Set("page", strconv.Itoa(p.Page.Number+1))
+		current.RawQuery = query.Encode()
+		return current.String(), nil
+	}
+	return "", nil
+}
+
+// Pages iterates over the pages of the HAL collection at url, following the next links until the last page.
+// The iteration stops after the first error.
+func (c *Client) Pages(ctx context.Context, url string, header map[string]string) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
+		visited := map[string]bool{}
+		for url != "" && !visited[url] {
+			visited[url] = true
+			var body json.RawMessage
+			if err := c.RequestAndMap(ctx, "GET", url, nil, header, &body); err != nil {
+				yield(nil, err)
+				return
+			}
+			page := &Page{url: url, body: body}
+			if len(body) > 0 {
+				if err := json.Unmarshal(body, page); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			next, err := page.nextURL()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+			url = next
+		}
+	}
+}
+
+// RequestAndMapAllPages fetches every page of the HAL collection at url and unmarshals the first page into m,
+// with the `_embedded` arrays of all pages combined.
+func (c *Client) RequestAndMapAllPages(ctx context.Context, url string, header map[string]string, m interface{}) error {
+	var first map[string]json.RawMessage
+	var relations []string
+	embedded := map[string][]json.RawMessage{}
+	for page, err := range c.Pages(ctx, url, header) {
+		if err != nil {
+			return err
+		}
+		if first == nil {
+			if len(page.body) == 0 {
+				return nil
+			}
+			if err := json.Unmarshal(page.body, &first); err != nil {
+				return err
+			}
+		}
+		for relation, items := range page.Embedded {
+			var elements []json.RawMessage
+			if err := json.Unmarshal(items, &elements); err != nil {
+				// Not a collection, keep the value of the first page.
+				continue
+			}
+			if _, ok := embedded[relation]; !ok {
+				relations = append(relations, relation)
+			}
+			embedded[relation] = append(embedded[relation], elements...)
+		}
+	}
+	if first == nil {
+		return nil
+	}
+
+	if len(relations) > 0 {
+		var firstEmbedded map[string]json.RawMessage
+		if err := json.Unmarshal(first["_embedded"], &firstEmbedded); err != nil || firstEmbedded == nil {
+			firstEmbedded = map[string]json.RawMessage{}
+		}
+		for _, relation := range relations {
+			items, err := json.Marshal(embedded[relation])
+			if err != nil {
+				return err
+			}
+			firstEmbedded[relation] = items
+		}
+		combined, err := json.Marshal(firstEmbedded)
+		if err != nil {
+			return err
+		}
+		first["_embedded"] = combined
+	}
+	body, err := json.Marshal(first)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, m)
+}
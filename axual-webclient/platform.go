@@ -0,0 +1,149 @@
+package webclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// PlatformInfo describes the Platform Manager behind the API, as reported by its build info.
+type PlatformInfo struct {
+	// Name is the name of the build, e.g. "platform-manager".
+	Name string
+	// Version is the Platform Manager version, e.g. "15.0.2". It is empty when the API does not expose its build info.
+	Version string
+	// BuildTime is the time the Platform Manager was built, as reported by the API.
+	BuildTime string
+}
+
+// PlatformVersion is a parsed major.minor.patch Platform Manager version.
+type PlatformVersion struct {
+	Major, Minor, Patch int
+}
+
+func (v PlatformVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the same as or later than other.
+func (v PlatformVersion) AtLeast(other PlatformVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// ParsePlatformVersion parses a version such as "15.0.2", "15.0" or "15.0.2-SNAPSHOT".
+func ParsePlatformVersion(version string) (PlatformVersion, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return PlatformVersion{}, fmt.Errorf("invalid platform version %q", version)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return PlatformVersion{}, fmt.Errorf("invalid platform version %q", version)
+		}
+		numbers[i] = n
+	}
+	return PlatformVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Feature is an API capability that depends on the Platform Manager version.
+type Feature struct {
+	Name       string
+	MinVersion PlatformVersion
+}
+
+var (
+	// FeatureInstanceSearchByName are the findByName and findByShortName searches of instances.
+	// Older platforms are searched with findByAttributes instead.
+	FeatureInstanceSearchByName = Feature{Name: "instance_search_by_name", MinVersion: PlatformVersion{Major: 15}}
+	// FeaturePrincipalRotation is the replacement of the certificate of a Connector application principal.
+	FeaturePrincipalRotation = Feature{Name: "principal_rotation", MinVersion: PlatformVersion{Major: 15}}
+)
+
+// Features lists every Feature known to the client.
+var Features = []Feature{
+	FeatureInstanceSearchByName,
+	FeaturePrincipalRotation,
+}
+
+// UnsupportedFeatureError is returned when the connected platform is too old for a Feature.
+type UnsupportedFeatureError struct {
+	Feature Feature
+	Version string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s requires Platform Manager %s or later, but the API runs version %s", e.Feature.Name, e.Feature.MinVersion, e.Version)
+}
+
+// actuatorInfo is the Spring Boot info endpoint response of the Platform Manager.
+type actuatorInfo struct {
+	Build struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Time    string `json:"time"`
+	} `json:"build"`
+}
+
+// DetectPlatform reads the build info of the Platform Manager and remembers it for Supports.
+// An API that does not expose its build info is not an error: the version stays unknown,
+// and every feature is assumed to be supported.
+func (c *Client) DetectPlatform(ctx context.Context) (*PlatformInfo, error) {
+	var info actuatorInfo
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/actuator/info", c.ApiURL), nil, map[string]string{"Accept": "application/json"}, &info)
+	if err != nil && !errors.Is(err, NotFoundError) && !errors.Is(err, ForbiddenError) && !errors.Is(err, UnauthorizedError) {
+		return nil, err
+	}
+	platform := &PlatformInfo{
+		Name:      info.Build.Name,
+		Version:   info.Build.Version,
+		BuildTime: info.Build.Time,
+	}
+	if platform.Version != "" {
+		if _, err := ParsePlatformVersion(platform.Version); err != nil {
+			tflog.SubsystemWarn(logContext(ctx), logSubsystem, "Ignoring the platform version", map[string]interface{}{"error": err.Error()})
+			platform.Version = ""
+		}
+	}
+	tflog.SubsystemDebug(logContext(ctx), logSubsystem, "Detected platform", map[string]interface{}{
+		"name":    platform.Name,
+		"version": platform.Version,
+	})
+	c.platform = platform
+	return platform, nil
+}
+
+// Platform returns the platform found by DetectPlatform, or nil when it was not called.
+func (c *Client) Platform() *PlatformInfo {
+	return c.platform
+}
+
+// Supports reports whether the connected platform supports the feature.
+// Without a detected version, the latest platform is assumed.
+func (c *Client) Supports(feature Feature) bool {
+	return c.RequireFeature(feature) == nil
+}
+
+// RequireFeature returns an UnsupportedFeatureError when the connected platform is too old for the feature.
+func (c *Client) RequireFeature(feature Feature) error {
+	if c.platform == nil || c.platform.Version == "" {
+		return nil
+	}
+	version, err := ParsePlatformVersion(c.platform.Version)
+	if err != nil || version.AtLeast(feature.MinVersion) {
+		return nil
+	}
+	return &UnsupportedFeatureError{Feature: feature, Version: c.platform.Version}
+}
@@ -0,0 +1,82 @@
+package webclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WaitTimeoutError is returned when the platform did not reach the expected state within the PollingPolicy timeout.
+var WaitTimeoutError = errors.New("timed out waiting for the platform")
+
+// PollingPolicy controls how the Client waits for changes to be applied by the platform,
+// such as ACLs of a topic config or an approved grant.
+type PollingPolicy struct {
+	// Interval is the wait between two checks of the state. It defaults to one second.
+	Interval time.Duration
+	// Timeout bounds the total wait. Zero only bounds the wait by the context.
+	Timeout time.Duration
+}
+
+// DefaultPollingPolicy returns the polling policy used by NewClient unless WithPollingPolicy is given.
+func DefaultPollingPolicy() PollingPolicy {
+	return PollingPolicy{
+		Interval: 1 * time.Second,
+		Timeout:  5 * time.Minute,
+	}
+}
+
+// WithPollingPolicy replaces the DefaultPollingPolicy of the Client.
+func WithPollingPolicy(policy PollingPolicy) Option {
+	return func(c *Client) {
+		c.PollingPolicy = policy
+	}
+}
+
+// waitFor checks ready until it reports true, waiting the polling interval between the checks.
+// An error of ready stops the wait. The description names the awaited state in logs and errors.
+func (c *Client) waitFor(ctx context.Context, description string, ready func(ctx context.Context) (bool, error)) error {
+	interval := c.PollingPolicy.Interval
+	if interval <= 0 {
+		interval = DefaultPollingPolicy().Interval
+	}
+	pollCtx := ctx
+	if c.PollingPolicy.Timeout > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, c.PollingPolicy.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	timedOut := func() error {
+		// The deadline of the operation, such as a resource timeout, ended the wait.
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %s after %s", ctx.Err(), description, time.Since(start).Round(time.Second))
+		}
+		return fmt.Errorf("%w: %s after %s", WaitTimeoutError, description, time.Since(start).Round(time.Second))
+	}
+	for attempt := 1; ; attempt++ {
+		done, err := ready(pollCtx)
+		if err == nil && done {
+			tflog.SubsystemDebug(logContext(ctx), logSubsystem, "Platform is ready", map[string]interface{}{
+				"state":       description,
+				"checks":      attempt,
+				"duration_ms": time.Since(start).Milliseconds(),
+			})
+			return nil
+		}
+		// A check cut short by the polling timeout counts as a timeout, not as a failed check.
+		if pollCtx.Err() != nil {
+			return timedOut()
+		}
+		if err != nil {
+			return err
+		}
+		if sleepContext(pollCtx, interval) != nil {
+			return timedOut()
+		}
+	}
+}
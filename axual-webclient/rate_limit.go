@@ -0,0 +1,80 @@
+package webclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit bounds the load the Client puts on the API, e.g. when Terraform runs many operations in parallel.
+// Every attempt of a request counts, including retries and the checks while waiting for the platform.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests of a token bucket. Zero disables the rate limit.
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent at once after a quiet period.
+	// It defaults to the requests of one second.
+	Burst int
+	// MaxConcurrentRequests bounds the requests in flight at the same time. Zero disables the bound.
+	MaxConcurrentRequests int
+}
+
+// WithRateLimit limits the rate and the concurrency of the API requests of the Client.
+// Token requests are not limited.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) {
+		c.limiter = newLimiter(limit)
+	}
+}
+
+// limiter combines a token bucket and a semaphore. A nil limiter lets every request through.
+type limiter struct {
+	bucket *rate.Limiter
+	slots  chan struct{}
+}
+
+func newLimiter(limit RateLimit) *limiter {
+	if limit.RequestsPerSecond <= 0 && limit.MaxConcurrentRequests <= 0 {
+		return nil
+	}
+	l := &limiter{}
+	if limit.RequestsPerSecond > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(limit.RequestsPerSecond))
+		}
+		l.bucket = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+	}
+	if limit.MaxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, limit.MaxConcurrentRequests)
+	}
+	return l
+}
+
+// acquire waits until a request may be sent. The returned function must be called when the request is done.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			release()
+			// The bucket fails early when the wait would exceed the deadline of the context.
+			if ctx.Err() == nil {
+				return nil, fmt.Errorf("waiting for the rate limit: %w", context.DeadlineExceeded)
+			}
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
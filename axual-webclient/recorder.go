@@ -0,0 +1,295 @@
+package webclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RecorderMode decides whether a Recorder sends requests to the platform or answers them from its cassette.
+type RecorderMode string
+
+const (
+	// RecordMode sends the requests to the platform and adds them with their responses to the cassette.
+	RecordMode RecorderMode = "record"
+	// ReplayMode answers the requests from the cassette without sending them.
+	ReplayMode RecorderMode = "replay"
+)
+
+// Cassette holds the interactions with the platform a Recorder captured, in the order they happened.
+// Credentials, tokens and private keys in the bodies are replaced before they are added.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Recorder records the API and token requests of a Client to a cassette file, or replays them from it,
+// so the mapping of real responses can be tested without a platform. See WithRecorder.
+type Recorder struct {
+	mode RecorderMode
+	path string
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. In ReplayMode the file must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	switch mode {
+	case RecordMode:
+	case ReplayMode:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid recorder mode: %s", mode)
+	}
+	return r, nil
+}
+
+// WithRecorder sends the API and token requests of the Client through the Recorder.
+func WithRecorder(recorder *Recorder) Option {
+	return func(c *Client) {
+		c.recorder = recorder
+	}
+}
+
+// Save writes the recorded cassette to its file. It does nothing in ReplayMode.
+func (r *Recorder) Save() error {
+	if r.mode != RecordMode {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// transport returns the RoundTripper that records the requests sent with base, or replays them.
+func (r *Recorder) transport(base http.RoundTripper) http.RoundTripper {
+	return &recorderTransport{recorder: r, base: base}
+}
+
+type recorderTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Body:   sanitizeBody(body, req.Header.Get("Content-Type")),
+	}
+	if t.recorder.mode == ReplayMode {
+		return t.recorder.replay(req, recorded)
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.recorder.mu.Lock()
+	t.recorder.cassette.Interactions = append(t.recorder.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        sanitizeBody(respBody, resp.Header.Get("Content-Type")),
+		},
+	})
+	t.recorder.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers the request with the first interaction not replayed yet with the same method, path and query,
+// preferring one with the same body, as Terraform sends requests to different resources in parallel. When all
+// matching interactions are replayed, the last one is repeated, so extra checks while waiting for the platform
+// see its final state. The host is ignored, so a cassette can be replayed with another API URL.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match, last := -1, -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request.Method != recorded.Method || !sameResource(interaction.Request.URL, req.URL) {
+			continue
+		}
+		last = i
+		if r.replayed[i] {
+			continue
+		}
+		if interaction.Request.Body == recorded.Body {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		match = last
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no interaction recorded for %s %s in cassette %s", req.Method, req.URL, r.path)
+	}
+	r.replayed[match] = true
+
+	response := r.cassette.Interactions[match].Response
+	header := http.Header{}
+	if response.ContentType != "" {
+		header.Set("Content-Type", response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// sameResource reports whether the recorded URL has the path and query of the request URL.
+func sameResource(recorded string, u *url.URL) bool {
+	r, err := url.Parse(recorded)
+	if err != nil {
+		return false
+	}
+	return r.Path == u.Path && r.Query().Encode() == u.Query().Encode()
+}
+
+// sensitiveFormFields are the fields of token requests that are replaced in cassettes.
+var sensitiveFormFields = []string{"password", "client_secret", "client_assertion", "subject_token", "refresh_token"}
+
+// sensitiveConfigKey matches the keys of connector and KSML configs whose values are replaced in cassettes.
+var sensitiveConfigKey = regexp.MustCompile(`(?i)password|secret|token|credential|jaas|private`)
+
+// sanitizeBody returns the body for a cassette, with credentials, tokens and private keys replaced.
+// Unlike redactBody, it keeps everything else, including configs, as the replayed responses are mapped.
+func sanitizeBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for _, field := range sensitiveFormFields {
+				if form.Has(field) {
+					form.Set(field, redacted)
+				}
+			}
+			return form.Encode()
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return privateKeyPattern.ReplaceAllString(string(body), redacted)
+	}
+	out, err := json.Marshal(sanitizeValue(value))
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch lower := strings.ToLower(key); {
+			case lower == "configs":
+				v[key] = sanitizeConfigs(field)
+			case sensitiveFields[lower] && lower != "configvalue":
+				v[key] = redacted
+			default:
+				v[key] = sanitizeValue(field)
+			}
+		}
+		// Configs of deployment responses are a list of key and value pairs.
+		if key, ok := v["configKey"].(string); ok && sensitiveConfigKey.MatchString(key) {
+			v["configValue"] = redacted
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = sanitizeValue(element)
+		}
+	case string:
+		return privateKeyPattern.ReplaceAllString(v, redacted)
+	}
+	return value
+}
+
+// sanitizeConfigs replaces the values of sensitive keys of a config map, and sanitizes a list of configs.
+func sanitizeConfigs(configs interface{}) interface{} {
+	m, ok := configs.(map[string]interface{})
+	if !ok {
+		return sanitizeValue(configs)
+	}
+	for key, value := range m {
+		if sensitiveConfigKey.MatchString(key) {
+			m[key] = redacted
+		} else {
+			m[key] = sanitizeValue(value)
+		}
+	}
+	return m
+}
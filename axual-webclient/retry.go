@@ -0,0 +1,136 @@
+package webclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the Client retries requests that failed with a transient error.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// MinWait is the backoff before the first retry; it doubles with every further retry.
+	MinWait time.Duration
+	// MaxWait caps the backoff and any Retry-After delay requested by the API.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient unless WithRetryPolicy is given.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 4,
+		MinWait:    1 * time.Second,
+		MaxWait:    30 * time.Second,
+	}
+}
+
+type conflictRetriesKey struct{}
+
+// conflictRetries marks requests that are also retried when the API rejects them with a conflict, such as a
+// change the API rejects while a change it depends on is still propagating to the Kafka cluster. It records
+// whether an attempt was retried after a server error, as the API may have processed that attempt anyway.
+type conflictRetries struct {
+	retriedServerError bool
+}
+
+// withConflictRetries returns a context whose requests are retried on conflicts, and the record of their retries.
+func withConflictRetries(ctx context.Context) (context.Context, *conflictRetries) {
+	retries := &conflictRetries{}
+	return context.WithValue(ctx, conflictRetriesKey{}, retries), retries
+}
+
+func conflictRetriesOf(ctx context.Context) *conflictRetries {
+	retries, _ := ctx.Value(conflictRetriesKey{}).(*conflictRetries)
+	return retries
+}
+
+// retried records a retry of a request that failed with err.
+func (r *conflictRetries) retried(err error) {
+	if apiErr, ok := AsAPIError(err); r != nil && ok && apiErr.StatusCode >= http.StatusInternalServerError {
+		r.retriedServerError = true
+	}
+}
+
+// retryDelay returns how long to wait before retry number attempt+1 of a request that failed with err,
+// and false when the request must not be retried. With retryConflicts, a request the API answered with
+// a conflict is retried whatever its method.
+func (p RetryPolicy) retryDelay(method string, retryConflicts bool, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		// A network error may have happened after the API processed the request,
+		// so only requests that can safely be repeated are retried.
+		if !isIdempotent(method) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+	if !isRetryableStatus(apiErr.StatusCode, isIdempotent(method), retryConflicts) {
+		return 0, false
+	}
+	if apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, p.MaxWait), true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential backoff for the given attempt with jitter, so parallel
+// requests that failed together do not retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MaxWait
+	if attempt < 32 && p.MinWait<<attempt > 0 && p.MinWait<<attempt < p.MaxWait {
+		wait = p.MinWait << attempt
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// isRetryableStatus reports whether a response status is worth retrying. The API normally did not process
+// a request rejected with 429 or 503, so those are retried for every request. Conflicts (a dependent
+// change that is still propagating) are retried when repeating the request is safe or retryConflicts
+// is set. Other server errors may come after the API processed the request, so they are only
+// retried when repeating the request is safe.
+func isRetryableStatus(status int, idempotent bool, retryConflicts bool) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusConflict:
+		return idempotent || retryConflicts
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
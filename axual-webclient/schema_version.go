@@ -1,13 +1,14 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
-func (c *Client) ValidateSchemaVersion(schema ValidateSchemaVersionRequest) (*ValidateSchemaVersionResponse, error) {
+func (c *Client) ValidateSchemaVersion(ctx context.Context, schema ValidateSchemaVersionRequest) (*ValidateSchemaVersionResponse, error) {
 	o := ValidateSchemaVersionResponse{}
 	marshal, err := json.Marshal(schema)
 	if err != nil {
@@ -17,7 +18,7 @@ func (c *Client) ValidateSchemaVersion(schema ValidateSchemaVersionRequest) (*Va
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
 	}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/schemas/check-parse", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/schemas/check-parse", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
 
 	if err != nil {
 		return nil, err
@@ -25,7 +26,7 @@ func (c *Client) ValidateSchemaVersion(schema ValidateSchemaVersionRequest) (*Va
 	return &o, nil
 }
 
-func (c *Client) CreateSchemaVersion(data SchemaVersionRequest) (*CreateSchemaVersionResponse, error) {
+func (c *Client) CreateSchemaVersion(ctx context.Context, data SchemaVersionRequest) (*CreateSchemaVersionResponse, error) {
 	o := CreateSchemaVersionResponse{}
 	marshal, err := json.Marshal(data)
 	if err != nil {
@@ -35,7 +36,7 @@ func (c *Client) CreateSchemaVersion(data SchemaVersionRequest) (*CreateSchemaVe
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
 	}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/schemas/upload", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/schemas/upload", c.ApiURL), strings.NewReader(string(marshal)), headers, &o)
 
 	if err != nil {
 		return nil, err
@@ -43,58 +44,58 @@ func (c *Client) CreateSchemaVersion(data SchemaVersionRequest) (*CreateSchemaVe
 	return &o, nil
 }
 
-func (c *Client) GetSchemaVersion(id string) (*GetSchemaVersionResponse, error) {
+func (c *Client) GetSchemaVersion(ctx context.Context, id string) (*GetSchemaVersionResponse, error) {
 	o := GetSchemaVersionResponse{}
 	headers := map[string]string{
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
 	}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/schema_versions/%v", c.ApiURL, id), nil, headers, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/schema_versions/%v", c.ApiURL, id), nil, headers, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) DeleteSchemaVersion(id string) error {
+func (c *Client) DeleteSchemaVersion(ctx context.Context, id string) error {
 	headers := map[string]string{
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
 	}
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/schema_versions/%v", c.ApiURL, id), nil, headers, nil)
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/schema_versions/%v", c.ApiURL, id), nil, headers, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) GetKeySchemaVersion(id string) (*GetSchemaVersionResponse, error) {
+func (c *Client) GetKeySchemaVersion(ctx context.Context, id string) (*GetSchemaVersionResponse, error) {
 	o := GetSchemaVersionResponse{}
 	headers := map[string]string{
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
 	}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/stream_configs/%v/keySchemaVersion", c.ApiURL, id), nil, headers, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/stream_configs/%v/keySchemaVersion", c.ApiURL, id), nil, headers, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) GetValueSchemaVersion(id string) (*GetSchemaVersionResponse, error) {
+func (c *Client) GetValueSchemaVersion(ctx context.Context, id string) (*GetSchemaVersionResponse, error) {
 	o := GetSchemaVersionResponse{}
 	headers := map[string]string{
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
 	}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/stream_configs/%v/valueSchemaVersion", c.ApiURL, id), nil, headers, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/stream_configs/%v/valueSchemaVersion", c.ApiURL, id), nil, headers, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) GetSchemaVersionsBySchema(id string) (*GetSchemaVersionsResponse, error) {
+func (c *Client) GetSchemaVersionsBySchema(ctx context.Context, id string) (*GetSchemaVersionsResponse, error) {
 	o := GetSchemaVersionsResponse{}
 	headers := map[string]string{
 		"Content-Type": "application/json",
@@ -103,14 +104,14 @@ func (c *Client) GetSchemaVersionsBySchema(id string) (*GetSchemaVersionsRespons
 	values := url.Values{}
 	values.Add("schema", id)
 	endpoint := fmt.Sprintf("%s/schema_versions/search/findAllBySchema?%s", c.ApiURL, values.Encode())
-	err := c.RequestAndMap("GET", endpoint, nil, headers, &o)
+	err := c.RequestAndMapAllPages(ctx, endpoint, headers, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) GetSchemaByName(name string) (*GetSchemaByNameResponse, error) {
+func (c *Client) GetSchemaByName(ctx context.Context, name string) (*GetSchemaByNameResponse, error) {
 	o := GetSchemaByNameResponse{}
 	headers := map[string]string{
 		"Content-Type": "application/json",
@@ -119,7 +120,7 @@ func (c *Client) GetSchemaByName(name string) (*GetSchemaByNameResponse, error)
 	values := url.Values{}
 	values.Add("name", name)
 	endpoint := fmt.Sprintf("%s/schemas/search/findByName?%s", c.ApiURL, values.Encode())
-	err := c.RequestAndMap("GET", endpoint, nil, headers, &o)
+	err := c.RequestAndMapAllPages(ctx, endpoint, headers, &o)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,302 @@
+package webclient_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	webclient "axual-webclient"
+)
+
+func TestClientCredentialsGrant(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc   string
+		auth   webclient.AuthStruct
+		verify func(t *testing.T, form map[string]string, tokenURL string)
+	}{
+		{
+			desc: "client secret",
+			auth: webclient.AuthStruct{ClientSecret: "secret", Audience: "https://api", Scopes: []string{"openid"}},
+			verify: func(t *testing.T, form map[string]string, _ string) {
+				if form["client_secret"] != "secret" || form["audience"] != "https://api" || form["scope"] != "openid" {
+					t.Errorf("unexpected token request: %v", form)
+				}
+			},
+		},
+		{
+			desc: "private_key_jwt with an RSA key",
+			auth: webclient.AuthStruct{PrivateKeyPEM: pkcs8PEM(t, rsaKey), PrivateKeyID: "key-1"},
+			verify: func(t *testing.T, form map[string]string, tokenURL string) {
+				verifyAssertion(t, form, tokenURL, "RS256", func(input, signature []byte) bool {
+					digest := sha256.Sum256(input)
+					return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature) == nil
+				})
+			},
+		},
+		{
+			desc: "private_key_jwt with an ECDSA key",
+			auth: webclient.AuthStruct{PrivateKeyPEM: pkcs8PEM(t, ecKey)},
+			verify: func(t *testing.T, form map[string]string, tokenURL string) {
+				verifyAssertion(t, form, tokenURL, "ES256", func(input, signature []byte) bool {
+					digest := sha256.Sum256(input)
+					r := new(big.Int).SetBytes(signature[:32])
+					s := new(big.Int).SetBytes(signature[32:])
+					return ecdsa.Verify(&ecKey.PublicKey, digest[:], r, s)
+				})
+			},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/token" {
+					if err := r.ParseForm(); err != nil {
+						t.Fatal(err)
+					}
+					form := map[string]string{}
+					for key := range r.PostForm {
+						form[key] = r.PostForm.Get(key)
+					}
+					if form["grant_type"] != "client_credentials" || form["client_id"] != "ci-pipeline" {
+						t.Errorf("unexpected token request: %v", form)
+					}
+					c.verify(t, form, server.URL+"/token")
+					_, _ = w.Write([]byte(`{"access_token":"service-token","token_type":"Bearer","expires_in":300}`))
+					return
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer service-token" {
+					t.Errorf("expected the service token, got %q", got)
+				}
+				_, _ = w.Write([]byte(`{"uid":"uid"}`))
+			}))
+			defer server.Close()
+
+			auth := c.auth
+			auth.Url = server.URL + "/token"
+			auth.ClientId = "ci-pipeline"
+			auth.AuthMode = "keycloak"
+			auth.GrantType = "client_credentials"
+			client, err := webclient.NewClient(context.Background(), server.URL, "axual", auth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetTopic(context.Background(), "uid"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSubjectTokenGrants(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-jwt\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CI_OIDC_TOKEN", "env-jwt")
+
+	testCases := []struct {
+		desc     string
+		auth     webclient.AuthStruct
+		wantForm map[string]string
+		wantErr  bool
+	}{
+		{
+			desc: "token exchange with a subject token file",
+			auth: webclient.AuthStruct{GrantType: "token_exchange", SubjectTokenFile: tokenFile, Audience: "https://api"},
+			wantForm: map[string]string{
+				"grant_type":         "urn:ietf:params:oauth:grant-type:token-exchange",
+				"subject_token":      "file-jwt",
+				"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+				"audience":           "https://api",
+			},
+		},
+		{
+			desc: "token exchange with a subject token environment variable and token type",
+			auth: webclient.AuthStruct{GrantType: "token_exchange", SubjectTokenEnv: "CI_OIDC_TOKEN", SubjectTokenType: "urn:ietf:params:oauth:token-type:id_token"},
+			wantForm: map[string]string{
+				"subject_token":      "env-jwt",
+				"subject_token_type": "urn:ietf:params:oauth:token-type:id_token",
+			},
+		},
+		{
+			desc: "jwt bearer grant with a client secret",
+			auth: webclient.AuthStruct{GrantType: "jwt_bearer", SubjectTokenEnv: "CI_OIDC_TOKEN", ClientSecret: "secret"},
+			wantForm: map[string]string{
+				"grant_type":    "urn:ietf:params:oauth:grant-type:jwt-bearer",
+				"assertion":     "env-jwt",
+				"client_secret": "secret",
+			},
+		},
+		{
+			desc:    "empty subject token is rejected",
+			auth:    webclient.AuthStruct{GrantType: "jwt_bearer", SubjectTokenEnv: "UNSET_OIDC_TOKEN"},
+			wantErr: true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Fatal(err)
+				}
+				for key, want := range c.wantForm {
+					if got := r.PostForm.Get(key); got != want {
+						t.Errorf("expected %s %q, got %q", key, want, got)
+					}
+				}
+				_, _ = w.Write([]byte(`{"access_token":"exchanged-token","token_type":"Bearer","expires_in":300}`))
+			}))
+			defer server.Close()
+
+			auth := c.auth
+			auth.Url = server.URL
+			auth.ClientId = "ci-pipeline"
+			auth.AuthMode = "keycloak"
+			_, err := webclient.SignIn(context.Background(), auth)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTokenSourcesArePerClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			_, _ = w.Write([]byte(`{"access_token":"token-` + r.PostForm.Get("username") + `","token_type":"Bearer","expires_in":300}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"uid":"` + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + `"}`))
+	}))
+	defer server.Close()
+
+	for _, user := range []string{"tenant-a", "tenant-b"} {
+		auth := webclient.AuthStruct{Username: user, Password: "password", Url: server.URL + "/token", ClientId: "self-service", AuthMode: "auth0"}
+		client, err := webclient.NewClient(context.Background(), server.URL, "axual", auth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		topic, err := client.GetTopic(context.Background(), "uid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if topic.Uid != "token-"+user {
+			t.Errorf("expected the token of %s, got %s", user, topic.Uid)
+		}
+	}
+}
+
+func TestStaticAccessTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			t.Error("expected no token request")
+		}
+		_, _ = w.Write([]byte(`{"uid":"` + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + `"}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	client, err := webclient.NewClient(ctx, server.URL, "axual", webclient.AuthStruct{AccessToken: "static-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topic, err := client.GetTopic(ctx, "uid"); err != nil || topic.Uid != "static-token" {
+		t.Fatalf("expected the static token, got %v, %v", topic, err)
+	}
+
+	// A token that expires within the refresh margin makes the file to be read again for every request.
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeToken := func(subject string) string {
+		claims, _ := json.Marshal(map[string]interface{}{"sub": subject, "exp": time.Now().Add(time.Second).Unix()})
+		token := "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(claims) + ".sig"
+		if err := os.WriteFile(tokenFile, []byte(token), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	first := writeToken("first")
+	client, err = webclient.NewClient(ctx, server.URL, "axual", webclient.AuthStruct{AccessTokenFile: tokenFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topic, err := client.GetTopic(ctx, "uid"); err != nil || topic.Uid != first {
+		t.Fatalf("expected the first token, got %v, %v", topic, err)
+	}
+	second := writeToken("second")
+	if topic, err := client.GetTopic(ctx, "uid"); err != nil || topic.Uid != second {
+		t.Fatalf("expected the rotated token, got %v, %v", topic, err)
+	}
+}
+
+func pkcs8PEM(t *testing.T, key crypto.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func verifyAssertion(t *testing.T, form map[string]string, tokenURL string, alg string, verify func(input, signature []byte) bool) {
+	if form["client_assertion_type"] != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" || form["client_secret"] != "" {
+		t.Errorf("unexpected client authentication: %v", form)
+	}
+	parts := strings.Split(form["client_assertion"], ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a compact JWS, got %q", form["client_assertion"])
+	}
+	var header, claims map[string]interface{}
+	decodeSegment(t, parts[0], &header)
+	decodeSegment(t, parts[1], &claims)
+	if header["alg"] != alg {
+		t.Errorf("expected alg %s, got %v", alg, header["alg"])
+	}
+	if claims["iss"] != "ci-pipeline" || claims["sub"] != "ci-pipeline" || claims["aud"] != tokenURL {
+		t.Errorf("unexpected claims: %v", claims)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verify([]byte(parts[0]+"."+parts[1]), signature) {
+		t.Error("client assertion signature does not verify")
+	}
+}
+
+func decodeSegment(t *testing.T, segment string, v interface{}) {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
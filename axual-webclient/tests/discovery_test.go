@@ -0,0 +1,119 @@
+package webclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+func TestOIDCDiscovery(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		issuerPath string
+		document   string
+		auth       webclient.AuthStruct
+		wantRealm  bool
+		wantErr    bool
+	}{
+		{
+			desc:       "keycloak issuer provides the token endpoint and auth mode",
+			issuerPath: "/realms/axual",
+			document:   `{"issuer":"{{issuer}}","token_endpoint":"{{server}}/token","grant_types_supported":["password","client_credentials"]}`,
+			auth:       webclient.AuthStruct{Username: "user", Password: "password"},
+			wantRealm:  true,
+		},
+		{
+			desc:       "auth0 issuer with a trailing slash",
+			issuerPath: "/",
+			document:   `{"issuer":"{{issuer}}","token_endpoint":"{{server}}/token"}`,
+			auth:       webclient.AuthStruct{GrantType: "client_credentials", ClientSecret: "secret"},
+		},
+		{
+			desc:       "keycloak issuer with a static token sends the realm header without discovery",
+			issuerPath: "/realms/axual",
+			auth:       webclient.AuthStruct{AccessToken: "static-token"},
+			wantRealm:  true,
+		},
+		{
+			desc:       "auth0 issuer with a static token sends no realm header",
+			issuerPath: "/",
+			auth:       webclient.AuthStruct{AccessToken: "static-token"},
+		},
+		{
+			desc:       "document of another issuer is rejected",
+			issuerPath: "/realms/axual",
+			document:   `{"issuer":"https://other","token_endpoint":"{{server}}/token"}`,
+			auth:       webclient.AuthStruct{Username: "user", Password: "password"},
+			wantErr:    true,
+		},
+		{
+			desc:       "unsupported grant type is rejected",
+			issuerPath: "/realms/axual",
+			document:   `{"issuer":"{{issuer}}","token_endpoint":"{{server}}/token","grant_types_supported":["authorization_code"]}`,
+			auth:       webclient.AuthStruct{Username: "user", Password: "password"},
+			wantErr:    true,
+		},
+		{
+			desc:       "unsupported client authentication is rejected",
+			issuerPath: "/realms/axual",
+			document:   `{"issuer":"{{issuer}}","token_endpoint":"{{server}}/token","token_endpoint_auth_methods_supported":["client_secret_basic"]}`,
+			auth:       webclient.AuthStruct{GrantType: "client_credentials", ClientSecret: "secret"},
+			wantErr:    true,
+		},
+		{
+			desc:       "missing discovery document is rejected",
+			issuerPath: "/realms/unknown",
+			auth:       webclient.AuthStruct{Username: "user", Password: "password"},
+			wantErr:    true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				issuer := server.URL + c.issuerPath
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/realms/axual/.well-known/openid-configuration", "/.well-known/openid-configuration":
+					if c.document == "" {
+						http.NotFound(w, r)
+						return
+					}
+					document := strings.NewReplacer("{{issuer}}", issuer, "{{server}}", server.URL).Replace(c.document)
+					_, _ = w.Write([]byte(document))
+				case "/token":
+					_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+				default:
+					if got := r.Header.Get("Realm") != ""; got != c.wantRealm {
+						t.Errorf("expected realm header %t, got %t", c.wantRealm, got)
+					}
+					_, _ = w.Write([]byte(`{"uid":"uid"}`))
+				}
+			}))
+			defer server.Close()
+
+			auth := c.auth
+			auth.Issuer = server.URL + c.issuerPath
+			auth.ClientId = "self-service"
+			client, err := webclient.NewClient(context.Background(), server.URL, "axual", auth)
+			if c.wantErr {
+				var discoveryErr *webclient.DiscoveryError
+				if !errors.As(err, &discoveryErr) {
+					t.Fatalf("expected a discovery error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetTopic(context.Background(), "uid"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package webclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+func TestAPIError(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		status      int
+		body        string
+		sentinel    error
+		message     string
+		fieldErrors []webclient.FieldError
+	}{
+		{
+			desc:     "not found matches NotFoundError",
+			status:   http.StatusNotFound,
+			sentinel: webclient.NotFoundError,
+		},
+		{
+			desc:     "spring error body",
+			status:   http.StatusConflict,
+			body:     `{"status":409,"error":"Conflict","message":"Topic with name already exists","path":"/api/streams"}`,
+			sentinel: webclient.ConflictError,
+			message:  "Topic with name already exists",
+		},
+		{
+			desc:     "spring data rest validation errors",
+			status:   http.StatusBadRequest,
+			body:     `{"errors":[{"entity":"Stream","property":"retentionPolicy","invalidValue":"x","message":"must be one of delete, compact"}]}`,
+			sentinel: nil,
+			fieldErrors: []webclient.FieldError{
+				{Field: "retentionPolicy", Message: "must be one of delete, compact", RejectedValue: "x"},
+			},
+		},
+		{
+			desc:     "problem details with invalid params",
+			status:   http.StatusUnprocessableEntity,
+			body:     `{"title":"Unprocessable Entity","detail":"Incompatible schema","invalid-params":[{"name":"valueSchemaVersion","reason":"not compatible"}]}`,
+			sentinel: webclient.UnprocessableEntityError,
+			message:  "Incompatible schema",
+			fieldErrors: []webclient.FieldError{
+				{Field: "valueSchemaVersion", Message: "not compatible"},
+			},
+		},
+		{
+			desc:     "forbidden with plain text body",
+			status:   http.StatusForbidden,
+			body:     `Access is denied`,
+			sentinel: webclient.ForbiddenError,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(c.status)
+				_, _ = w.Write([]byte(c.body))
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+			_, err := client.GetTopic(context.Background(), "uid")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			apiErr, ok := webclient.AsAPIError(err)
+			if !ok {
+				t.Fatalf("expected an APIError, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != c.status || apiErr.Method != http.MethodGet {
+				t.Fatalf("unexpected status or method: %d %s", apiErr.StatusCode, apiErr.Method)
+			}
+			if c.sentinel != nil && !errors.Is(err, c.sentinel) {
+				t.Fatalf("expected error to match %v", c.sentinel)
+			}
+			if apiErr.Message != c.message {
+				t.Fatalf("expected message %q, got %q", c.message, apiErr.Message)
+			}
+			if len(apiErr.FieldErrors) != len(c.fieldErrors) {
+				t.Fatalf("expected %d field errors, got %d", len(c.fieldErrors), len(apiErr.FieldErrors))
+			}
+			for i, fe := range c.fieldErrors {
+				got := apiErr.FieldErrors[i]
+				if got.Field != fe.Field || got.Message != fe.Message || got.RejectedValue != fe.RejectedValue {
+					t.Fatalf("expected field error %+v, got %+v", fe, got)
+				}
+			}
+		})
+	}
+}
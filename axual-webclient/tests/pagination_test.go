@@ -0,0 +1,155 @@
+package webclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+func TestPagination(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		pages func(serverURL string, page string) string
+	}{
+		{
+			desc: "next links are followed",
+			pages: func(serverURL string, page string) string {
+				switch page {
+				case "":
+					return `{"_embedded":{"schema_versions":[{"version":"1"}]},"_links":{"next":{"href":"` + serverURL + `/schema_versions/search/findAllBySchema?schema=uid&page=1{&sort}"}}}`
+				case "1":
+					return `{"_embedded":{"schema_versions":[{"version":"2"}]},"_links":{"next":{"href":"/schema_versions/search/findAllBySchema?schema=uid&page=2"}}}`
+				default:
+					return `{"_embedded":{"schema_versions":[{"version":"3"}]},"_links":{}}`
+				}
+			},
+		},
+		{
+			desc: "page metadata is followed without next links",
+			pages: func(_ string, page string) string {
+				number := map[string]int{"": 0, "1": 1, "2": 2}[page]
+				return fmt.Sprintf(`{"_embedded":{"schema_versions":[{"version":"%d"}]},"page":{"size":1,"totalElements":3,"totalPages":3,"number":%d}}`, number+1, number)
+			},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("schema") != "uid" {
+					t.Errorf("expected the search parameters to be kept, got %s", r.URL.RawQuery)
+				}
+				_, _ = w.Write([]byte(c.pages(server.URL, r.URL.Query().Get("page"))))
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+			versions, err := client.GetSchemaVersionsBySchema(context.Background(), "uid")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, version := range versions.Embedded.SchemaVersion {
+				got = append(got, version.Version)
+			}
+			if fmt.Sprint(got) != "[1 2 3]" {
+				t.Fatalf("expected the versions of all pages, got %v", got)
+			}
+		})
+	}
+}
+
+func TestPagesStopsOnRepeatedLink(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"_embedded":{"streams":[]},"_links":{"next":{"href":"/streams?page=1"}}}`))
+	}))
+	defer server.Close()
+
+	client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+	for _, err := range client.Pages(context.Background(), server.URL+"/streams", nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestListCollections(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		relation string
+		list     func(client *webclient.Client) ([]string, error)
+	}{
+		{
+			desc:     "applications",
+			relation: "applications",
+			list: func(client *webclient.Client) ([]string, error) {
+				applications, err := client.ListApplications(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				var uids []string
+				for _, application := range applications.Embedded.Applications {
+					uids = append(uids, application.Uid)
+				}
+				return uids, nil
+			},
+		},
+		{
+			desc:     "topic configs",
+			relation: "stream_configs",
+			list: func(client *webclient.Client) ([]string, error) {
+				configs, err := client.ListTopicConfigs(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				var uids []string
+				for _, config := range configs.Embedded.TopicConfigs {
+					uids = append(uids, config.Uid)
+				}
+				return uids, nil
+			},
+		},
+		{
+			desc:     "groups",
+			relation: "groups",
+			list: func(client *webclient.Client) ([]string, error) {
+				groups, err := client.ListGroups(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				var uids []string
+				for _, group := range groups.Embedded.Groups {
+					uids = append(uids, group.Uid)
+				}
+				return uids, nil
+			},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				number := map[string]int{"": 0, "1": 1}[r.URL.Query().Get("page")]
+				_, _ = fmt.Fprintf(w, `{"_embedded":{"%s":[{"uid":"uid-%d"}]},"page":{"size":1,"totalElements":2,"totalPages":2,"number":%d}}`, c.relation, number+1, number)
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+			uids, err := c.list(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(uids) != "[uid-1 uid-2]" {
+				t.Fatalf("expected the %s of all pages, got %v", c.desc, uids)
+			}
+		})
+	}
+}
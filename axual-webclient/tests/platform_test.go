@@ -0,0 +1,110 @@
+package webclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+func TestPlatformDetection(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		info           string
+		wantVersion    string
+		wantRotation   bool
+		wantSearchPath string
+	}{
+		{
+			desc:           "a current platform uses the findByName search",
+			info:           `{"build":{"name":"platform-manager","version":"15.0.2","time":"2026-06-01T10:00:00Z"}}`,
+			wantVersion:    "15.0.2",
+			wantRotation:   true,
+			wantSearchPath: "/instances/search/findByName",
+		},
+		{
+			desc:           "an older platform falls back to the findByAttributes search",
+			info:           `{"build":{"name":"platform-manager","version":"14.0.4-SNAPSHOT"}}`,
+			wantVersion:    "14.0.4-SNAPSHOT",
+			wantSearchPath: "/instances/search/findByAttributes",
+		},
+		{
+			desc:           "a platform without build info is assumed to be current",
+			wantRotation:   true,
+			wantSearchPath: "/instances/search/findByName",
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var searchPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/actuator/info":
+					if c.info == "" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					_, _ = w.Write([]byte(c.info))
+				case "/instances/search/findByAttributes":
+					searchPath = r.URL.Path
+					_, _ = w.Write([]byte(`{"_embedded":{"instances":[{"uid":"instance","name":"Dev"}]}}`))
+				default:
+					searchPath = r.URL.Path
+					_, _ = w.Write([]byte(`{"uid":"instance","name":"Dev"}`))
+				}
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+			platform, err := client.DetectPlatform(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if platform.Version != c.wantVersion {
+				t.Errorf("expected version %q, got %q", c.wantVersion, platform.Version)
+			}
+
+			err = client.RequireFeature(webclient.FeaturePrincipalRotation)
+			var unsupported *webclient.UnsupportedFeatureError
+			if c.wantRotation != (err == nil) || (err != nil && !errors.As(err, &unsupported)) {
+				t.Errorf("expected principal rotation support to be %t, got %v", c.wantRotation, err)
+			}
+
+			instance, err := client.GetInstanceByName(context.Background(), "Dev")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if instance.Uid != "instance" || searchPath != c.wantSearchPath {
+				t.Errorf("expected instance to be found with %s, got %q with %s", c.wantSearchPath, instance.Uid, searchPath)
+			}
+		})
+	}
+}
+
+func TestParsePlatformVersion(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		version string
+		want    webclient.PlatformVersion
+		wantErr bool
+	}{
+		{desc: "full version", version: "15.0.2", want: webclient.PlatformVersion{Major: 15, Minor: 0, Patch: 2}},
+		{desc: "version without patch", version: "14.1", want: webclient.PlatformVersion{Major: 14, Minor: 1}},
+		{desc: "pre-release version", version: "v16.0.0-SNAPSHOT", want: webclient.PlatformVersion{Major: 16}},
+		{desc: "invalid version", version: "latest", wantErr: true},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := webclient.ParsePlatformVersion(c.version)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
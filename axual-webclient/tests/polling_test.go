@@ -0,0 +1,200 @@
+package webclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	webclient "axual-webclient"
+)
+
+func TestReadinessPolling(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		statuses []string
+		wantErr  error
+	}{
+		{
+			desc:     "approval returns as soon as the grant is approved",
+			statuses: []string{"Pending", "Pending", "Approved"},
+		},
+		{
+			desc:     "approval fails when the grant is rejected",
+			statuses: []string{"Pending", "Rejected"},
+			wantErr:  errors.New("rejected"),
+		},
+		{
+			desc:     "approval times out when the grant stays pending",
+			statuses: []string{"Pending"},
+			wantErr:  webclient.WaitTimeoutError,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var reads atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					return
+				}
+				n := int(reads.Add(1))
+				status := c.statuses[min(n, len(c.statuses))-1]
+				_, _ = w.Write([]byte(`{"uid":"grant","status":"` + status + `"}`))
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{
+				HTTPClient:    server.Client(),
+				ApiURL:        server.URL,
+				PollingPolicy: webclient.PollingPolicy{Interval: time.Millisecond, Timeout: 100 * time.Millisecond},
+			}
+			err := client.ApproveGrant(context.Background(), "grant")
+			switch {
+			case c.wantErr == nil && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case c.wantErr == webclient.WaitTimeoutError && !errors.Is(err, webclient.WaitTimeoutError):
+				t.Fatalf("expected a timeout, got %v", err)
+			case c.wantErr != nil && err == nil:
+				t.Fatal("expected an error")
+			}
+			if c.wantErr == nil && int(reads.Load()) != len(c.statuses) {
+				t.Fatalf("expected %d reads, got %d", len(c.statuses), reads.Load())
+			}
+		})
+	}
+}
+
+func TestTopicConfigDeleteWaitsUntilRemoved(t *testing.T) {
+	var reads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if reads.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"uid":"config"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &webclient.Client{
+		HTTPClient:    server.Client(),
+		ApiURL:        server.URL,
+		PollingPolicy: webclient.PollingPolicy{Interval: time.Millisecond, Timeout: time.Second},
+	}
+	if err := client.DeleteTopicConfig(context.Background(), "config"); err != nil {
+		t.Fatal(err)
+	}
+	if reads.Load() != 3 {
+		t.Fatalf("expected 3 reads, got %d", reads.Load())
+	}
+}
+
+func TestPollingEndsWithContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			return
+		}
+		_, _ = w.Write([]byte(`{"uid":"grant","status":"Pending"}`))
+	}))
+	defer server.Close()
+
+	client := &webclient.Client{
+		HTTPClient:    server.Client(),
+		ApiURL:        server.URL,
+		PollingPolicy: webclient.PollingPolicy{Interval: time.Millisecond, Timeout: time.Minute},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := client.ApproveGrant(ctx, "grant")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "grant") {
+		t.Fatalf("expected the deadline of the context naming the grant, got %v", err)
+	}
+}
+
+func TestWaitForApplicationDeploymentOperation(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		action          string
+		applicationType string
+		statuses        []string
+		wantErr         error
+	}{
+		{
+			desc:            "start returns as soon as the connector runs",
+			action:          "START",
+			applicationType: "Connector",
+			statuses:        []string{`{"connectorState":{"state":"Stopped"}}`, `{"connectorState":{"state":"Running"}}`},
+		},
+		{
+			desc:            "start fails when the connector fails",
+			action:          "START",
+			applicationType: "Connector",
+			statuses:        []string{`{"connectorState":{"state":"Stopped"}}`, `{"connectorState":{"state":"Failed"}}`},
+			wantErr:         errors.New("failed to start"),
+		},
+		{
+			desc:            "start waits for a connector that failed in an earlier run",
+			action:          "START",
+			applicationType: "Connector",
+			statuses:        []string{`{"connectorState":{"state":"Failed"}}`, `{"connectorState":{"state":"Unassigned"}}`, `{"connectorState":{"state":"Running"}}`},
+		},
+		{
+			desc:            "start times out when the connector stays failed",
+			action:          "START",
+			applicationType: "Connector",
+			statuses:        []string{`{"connectorState":{"state":"Failed"}}`},
+			wantErr:         webclient.WaitTimeoutError,
+		},
+		{
+			desc:            "start only checks the status of the deployment type",
+			action:          "START",
+			applicationType: "Ksml",
+			statuses:        []string{`{"connectorState":{"state":"Running"},"ksmlStatus":{"status":"Deploying"}}`, `{"ksmlStatus":{"status":"Running"}}`},
+		},
+		{
+			desc:            "stop returns as soon as the KSML application is undeployed",
+			action:          "STOP",
+			applicationType: "Ksml",
+			statuses:        []string{`{"ksmlStatus":{"status":"Running"}}`, `{"ksmlStatus":{"status":"Undeployed"}}`},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var reads atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/status") {
+					_, _ = w.Write([]byte(`{"uid":"deployment","_embedded":{"application":{"applicationType":"` + c.applicationType + `"}}}`))
+					return
+				}
+				n := int(reads.Add(1))
+				_, _ = w.Write([]byte(c.statuses[min(n, len(c.statuses))-1]))
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{
+				HTTPClient:    server.Client(),
+				ApiURL:        server.URL,
+				PollingPolicy: webclient.PollingPolicy{Interval: time.Millisecond, Timeout: 100 * time.Millisecond},
+			}
+			err := client.WaitForApplicationDeploymentOperation(context.Background(), "deployment", c.action)
+			switch {
+			case c.wantErr == nil && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case c.wantErr == webclient.WaitTimeoutError && !errors.Is(err, webclient.WaitTimeoutError):
+				t.Fatalf("expected a timeout, got %v", err)
+			case c.wantErr != nil && (err == nil || !strings.Contains(err.Error(), c.wantErr.Error())):
+				t.Fatalf("expected an error containing %q, got %v", c.wantErr, err)
+			}
+			if c.wantErr == nil && int(reads.Load()) != len(c.statuses) {
+				t.Fatalf("expected %d reads, got %d", len(c.statuses), reads.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package webclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	webclient "axual-webclient"
+)
+
+func TestRateLimit(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		limit         webclient.RateLimit
+		maxInFlight   int32
+		minDuration   time.Duration
+		concurrent    int
+		requestLength time.Duration
+	}{
+		{
+			desc:          "concurrent requests are bounded by max concurrent requests",
+			limit:         webclient.RateLimit{MaxConcurrentRequests: 2},
+			maxInFlight:   2,
+			concurrent:    8,
+			requestLength: 20 * time.Millisecond,
+		},
+		{
+			desc:        "requests are spread by the requests per second",
+			limit:       webclient.RateLimit{RequestsPerSecond: 50, Burst: 1},
+			maxInFlight: 6,
+			minDuration: 100 * time.Millisecond,
+			concurrent:  6,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					current := maxInFlight.Load()
+					if n <= current || maxInFlight.CompareAndSwap(current, n) {
+						break
+					}
+				}
+				time.Sleep(c.requestLength)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+			webclient.WithRateLimit(c.limit)(client)
+
+			start := time.Now()
+			var wg sync.WaitGroup
+			for range c.concurrent {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := client.RequestAndMap(context.Background(), "GET", server.URL, nil, nil, nil); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if maxInFlight.Load() > c.maxInFlight {
+				t.Errorf("expected at most %d requests in flight, got %d", c.maxInFlight, maxInFlight.Load())
+			}
+			if elapsed := time.Since(start); elapsed < c.minDuration {
+				t.Errorf("expected the requests to take at least %s, took %s", c.minDuration, elapsed)
+			}
+		})
+	}
+}
+
+func TestRateLimitEndsWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &webclient.Client{HTTPClient: server.Client(), ApiURL: server.URL}
+	webclient.WithRateLimit(webclient.RateLimit{RequestsPerSecond: 0.1})(client)
+	if err := client.RequestAndMap(context.Background(), "GET", server.URL, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.RequestAndMap(ctx, "GET", server.URL, nil, nil, nil); err == nil {
+		t.Fatal("expected the second request to exceed the deadline while waiting for the rate limit")
+	}
+}
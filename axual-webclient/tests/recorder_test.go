@@ -0,0 +1,122 @@
+package webclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+const deploymentBody = `{
+	"uid": "deployment-uid",
+	"state": "Running",
+	"configs": [
+		{"configKey": "topics", "configValue": "orders"},
+		{"configKey": "database.password", "configValue": "db-secret"}
+	],
+	"_embedded": {"application": {"uid": "app-uid", "shortName": "orders", "applicationType": "Connector"}}
+}`
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/token":
+			_, _ = w.Write([]byte(`{"access_token":"live-token","token_type":"Bearer","expires_in":300,"refresh_token":"live-refresh"}`))
+		case "/application_deployments/deployment-uid":
+			_, _ = w.Write([]byte(deploymentBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	cassette := filepath.Join(t.TempDir(), "cassettes", "recorder.json")
+	auth := webclient.AuthStruct{Username: "user", Password: "user-password", Url: server.URL + "/token", ClientId: "self-service", AuthMode: "keycloak"}
+
+	recorder, err := webclient.NewRecorder(cassette, webclient.RecordMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := webclient.NewClient(context.Background(), server.URL, "axual", auth, webclient.WithRecorder(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := client.GetApplicationDeployment(context.Background(), "deployment-uid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"user-password", "live-token", "live-refresh", "db-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("expected %q to be scrubbed from the cassette:\n%s", secret, data)
+		}
+	}
+
+	// The server is gone, so the replayed client only sees the cassette.
+	replayer, err := webclient.NewRecorder(cassette, webclient.ReplayMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = webclient.NewClient(context.Background(), "https://platform.invalid", "axual", auth,
+		webclient.WithRecorder(replayer), webclient.WithRetryPolicy(webclient.RetryPolicy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := client.GetApplicationDeployment(context.Background(), "deployment-uid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Uid != recorded.Uid || replayed.Embedded.Application.ApplicationType != "Connector" ||
+		len(replayed.Configs) != 2 || replayed.Configs[0].ConfigValue != "orders" {
+		t.Errorf("expected the recorded deployment, got %+v", replayed)
+	}
+	if _, err := client.GetApplicationDeployment(context.Background(), "other-uid"); err == nil || !strings.Contains(err.Error(), "no interaction recorded") {
+		t.Errorf("expected an error for a request that was not recorded, got %v", err)
+	}
+}
+
+func TestRecorderReplayOrder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	// The deployment was stopped while waiting for it, so the checks see the states in the recorded order,
+	// and the last state once the recorded checks are used up.
+	err := os.WriteFile(cassette, []byte(`{"interactions": [
+		{"request": {"method": "GET", "url": "https://platform.local/api/application_deployments/d/status"},
+		 "response": {"status": 200, "contentType": "application/json", "body": "{\"connectorState\":{\"state\":\"Running\"}}"}},
+		{"request": {"method": "GET", "url": "https://platform.local/api/application_deployments/d/status"},
+		 "response": {"status": 200, "contentType": "application/json", "body": "{\"connectorState\":{\"state\":\"Stopped\"}}"}}
+	]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer, err := webclient.NewRecorder(cassette, webclient.ReplayMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := webclient.NewClient(context.Background(), "https://platform.local/api", "axual",
+		webclient.AuthStruct{AccessToken: "token"}, webclient.WithRecorder(replayer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"Running", "Stopped", "Stopped"} {
+		status, err := client.GetApplicationDeploymentStatus(context.Background(), "d")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.ConnectorState.State != want {
+			t.Errorf("expected state %s, got %s", want, status.ConnectorState.State)
+		}
+	}
+}
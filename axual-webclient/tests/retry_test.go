@@ -0,0 +1,228 @@
+package webclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	webclient "axual-webclient"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		method       string
+		statuses     []int
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			desc:         "GET is retried on 503 until it succeeds",
+			method:       http.MethodGet,
+			statuses:     []int{503, 503, 200},
+			wantRequests: 3,
+		},
+		{
+			desc:         "POST is retried on 429",
+			method:       http.MethodPost,
+			statuses:     []int{429, 201},
+			wantRequests: 2,
+		},
+		{
+			desc:         "POST is not retried on 502",
+			method:       http.MethodPost,
+			statuses:     []int{502, 201},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			desc:         "POST is not retried on 409",
+			method:       http.MethodPost,
+			statuses:     []int{409, 201},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			desc:         "400 is never retried",
+			method:       http.MethodGet,
+			statuses:     []int{400, 200},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			desc:         "retries stop after MaxRetries",
+			method:       http.MethodDelete,
+			statuses:     []int{503, 503, 503, 503, 200},
+			wantRequests: 3,
+			wantErr:      true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != `{"name":"x"}` {
+					t.Errorf("request %d: expected the body to be resent, got %q", n, body)
+				}
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(c.statuses[n-1])
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{
+				HTTPClient:  server.Client(),
+				ApiURL:      server.URL,
+				RetryPolicy: webclient.RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond},
+			}
+			err := client.RequestAndMap(context.Background(), c.method, server.URL+"/streams", strings.NewReader(`{"name":"x"}`), nil, nil)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := requests.Load(); got != c.wantRequests {
+				t.Fatalf("expected %d requests, got %d", c.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestTopicConfigCreate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		statuses  []int
+		wantPosts int32
+		wantUid   string
+	}{
+		{
+			desc:      "conflicts while the topic propagates are retried",
+			statuses:  []int{409, 409, 201},
+			wantPosts: 3,
+			wantUid:   "created",
+		},
+		{
+			desc:      "a server error the API may have processed is not retried",
+			statuses:  []int{500, 201},
+			wantPosts: 1,
+		},
+		{
+			desc:      "a conflict after a retried 503 returns the config created by the failed attempt",
+			statuses:  []int{503, 409},
+			wantPosts: 3,
+			wantUid:   "existing",
+		},
+		{
+			desc:      "a conflict without a server error does not take over an existing config",
+			statuses:  []int{409},
+			wantPosts: 3,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var posts, searches atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				embedded := `"_embedded":{"stream":{"uid":"topic"},"environment":{"uid":"env"}}`
+				switch {
+				case r.Method == http.MethodPost:
+					n := int(posts.Add(1))
+					w.WriteHeader(c.statuses[min(n, len(c.statuses))-1])
+					_, _ = w.Write([]byte(`{"uid":"created",` + embedded + `}`))
+				case r.URL.Path == "/stream_configs":
+					_, _ = w.Write([]byte(`{"_embedded":{"stream_configs":[{"uid":"existing",` + embedded + `}]}}`))
+				case strings.HasPrefix(r.URL.Path, "/application_access_grants/search"):
+					// The grants of the topic are only served once its config is applied.
+					if searches.Add(1) == 1 {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := &webclient.Client{
+				HTTPClient:    server.Client(),
+				ApiURL:        server.URL,
+				RetryPolicy:   webclient.RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond},
+				PollingPolicy: webclient.PollingPolicy{Interval: time.Millisecond, Timeout: time.Second},
+			}
+			config, err := client.CreateTopicConfig(context.Background(), webclient.TopicConfigRequest{
+				Stream:      server.URL + "/streams/topic",
+				Environment: server.URL + "/environments/env",
+			})
+			if got := posts.Load(); got != c.wantPosts {
+				t.Errorf("expected %d requests, got %d", c.wantPosts, got)
+			}
+			if c.wantUid == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got config %q", config.Uid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Uid != c.wantUid {
+				t.Errorf("expected config %q, got %q", c.wantUid, config.Uid)
+			}
+			if searches.Load() != 2 {
+				t.Errorf("expected to wait until the grants of the topic are served, got %d searches", searches.Load())
+			}
+		})
+	}
+}
+
+func TestPrincipalActivationIsRetriedOnConflict(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &webclient.Client{
+		HTTPClient:  server.Client(),
+		ApiURL:      server.URL,
+		RetryPolicy: webclient.RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond},
+	}
+	if err := client.ActivateApplicationPrincipal(context.Background(), "principal"); err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests.Load())
+	}
+}
+
+func TestRetryStopsWhenContextIsCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &webclient.Client{
+		HTTPClient:  server.Client(),
+		ApiURL:      server.URL,
+		RetryPolicy: webclient.RetryPolicy{MaxRetries: 5, MinWait: time.Second, MaxWait: time.Minute},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.GetTopic(ctx, "uid"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the retry wait to be cancelled, took %s", elapsed)
+	}
+}
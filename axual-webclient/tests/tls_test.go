@@ -0,0 +1,71 @@
+package webclient_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+func TestTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"uid":"uid","name":"topic"}`))
+	}))
+	defer server.Close()
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	testCases := []struct {
+		desc      string
+		tlsConfig webclient.TLSConfig
+		wantErr   bool
+	}{
+		{
+			desc:    "untrusted server certificate is rejected",
+			wantErr: true,
+		},
+		{
+			desc:      "server certificate signed by the configured CA is trusted",
+			tlsConfig: webclient.TLSConfig{CACertPEM: caCert},
+		},
+		{
+			desc:      "verification can explicitly be skipped",
+			tlsConfig: webclient.TLSConfig{InsecureSkipVerify: true},
+		},
+		{
+			desc:      "client certificate without a key is rejected",
+			tlsConfig: webclient.TLSConfig{CACertPEM: caCert, ClientCertPEM: caCert},
+			wantErr:   true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx := context.Background()
+			auth := webclient.AuthStruct{
+				Username: "user",
+				Password: "password",
+				Url:      server.URL + "/token",
+				ClientId: "self-service",
+				AuthMode: "keycloak",
+			}
+			client, err := webclient.NewClient(ctx, server.URL, "axual", auth, webclient.WithTLSConfig(c.tlsConfig))
+			if err == nil {
+				_, err = client.GetTopic(ctx, "uid")
+			}
+			if (err != nil) != c.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	if tlsConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig; tlsConfig != nil && tlsConfig.InsecureSkipVerify {
+		t.Fatal("expected the default transport to be left untouched")
+	}
+}
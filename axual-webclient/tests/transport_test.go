@@ -0,0 +1,124 @@
+package webclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	webclient "axual-webclient"
+)
+
+func TestCustomHeaders(t *testing.T) {
+	var tokenTenant, apiTenant, apiRealm string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenTenant = r.Header.Get("X-Tenant")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+			return
+		}
+		apiTenant = r.Header.Get("X-Tenant")
+		apiRealm = r.Header.Get("Realm")
+		_, _ = w.Write([]byte(`{"uid":"uid"}`))
+	}))
+	defer server.Close()
+
+	auth := webclient.AuthStruct{Username: "user", Password: "password", Url: server.URL + "/token", ClientId: "self-service", AuthMode: "keycloak"}
+	client, err := webclient.NewClient(context.Background(), server.URL, "axual", auth,
+		webclient.WithHeaders(map[string]string{"X-Tenant": "acme", "Realm": "other"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTopic(context.Background(), "uid"); err != nil {
+		t.Fatal(err)
+	}
+	if tokenTenant != "acme" || apiTenant != "acme" {
+		t.Errorf("expected the header on the token and API requests, got %q and %q", tokenTenant, apiTenant)
+	}
+	if apiRealm != "axual" {
+		t.Errorf("expected the realm header of the client to be kept, got %q", apiRealm)
+	}
+}
+
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestTokenHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	auth := webclient.AuthStruct{Username: "user", Password: "password", Url: server.URL + "/token", ClientId: "self-service", AuthMode: "keycloak",
+		HTTPClient: &http.Client{Transport: transport}}
+	if _, err := webclient.NewClient(context.Background(), server.URL, "axual", auth); err != nil {
+		t.Fatal(err)
+	}
+	if transport.requests.Load() != 1 {
+		t.Fatalf("expected the token request to use the given HTTP client, got %d requests", transport.requests.Load())
+	}
+}
+
+func TestProxy(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		noProxy   []string
+		wantProxy bool
+	}{
+		{
+			desc:      "API requests are sent through the proxy",
+			wantProxy: true,
+		},
+		{
+			desc:    "hosts in no proxy are reached directly",
+			noProxy: []string{"internal.invalid"},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			var proxied atomic.Int32
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxied.Add(1)
+				if r.URL.Host != "api.internal.invalid" {
+					t.Errorf("unexpected proxied host %q", r.URL.Host)
+				}
+				_, _ = w.Write([]byte(`{"uid":"uid"}`))
+			}))
+			defer proxy.Close()
+
+			// Requests to the loopback token endpoint are never proxied.
+			token := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+			}))
+			defer token.Close()
+
+			auth := webclient.AuthStruct{Username: "user", Password: "password", Url: token.URL, ClientId: "self-service", AuthMode: "keycloak"}
+			client, err := webclient.NewClient(context.Background(), "http://api.internal.invalid", "axual", auth,
+				webclient.WithProxy(webclient.ProxyConfig{URL: proxy.URL, NoProxy: c.noProxy}),
+				webclient.WithRetryPolicy(webclient.RetryPolicy{}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = client.GetTopic(context.Background(), "uid")
+			if c.wantProxy && (err != nil || proxied.Load() != 1) {
+				t.Fatalf("expected the request to be proxied, got %d requests and %v", proxied.Load(), err)
+			}
+			if !c.wantProxy && (err == nil || proxied.Load() != 0) {
+				t.Fatalf("expected a direct request to the unresolvable host, got %d proxied requests", proxied.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package webclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig holds the TLS settings for the connections to the API and the token endpoint.
+// Certificates can be given as PEM content or as a path to a PEM file; the content takes precedence.
+type TLSConfig struct {
+	// CACertPEM and CACertFile hold additional CA certificates trusted next to the system pool.
+	CACertPEM  string
+	CACertFile string
+	// ClientCertPEM/ClientKeyPEM and ClientCertFile/ClientKeyFile hold the client certificate for mTLS.
+	ClientCertPEM  string
+	ClientKeyPEM   string
+	ClientCertFile string
+	ClientKeyFile  string
+	// InsecureSkipVerify disables the verification of the server certificate. Only use it for testing.
+	InsecureSkipVerify bool
+}
+
+// WithTLSConfig configures the TLS settings of the transport owned by the Client.
+func WithTLSConfig(config TLSConfig) Option {
+	return func(c *Client) {
+		c.tlsConfig = config
+	}
+}
+
+// build returns the crypto/tls configuration, or nil when the defaults of Go can be used.
+func (t TLSConfig) build() (*tls.Config, error) {
+	if t == (TLSConfig{}) {
+		return nil, nil
+	}
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	caCert, err := pemContent("CA certificate", t.CACertPEM, t.CACertFile)
+	if err != nil {
+		return nil, err
+	}
+	if caCert != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid PEM certificate found in the CA certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	clientCert, err := pemContent("client certificate", t.ClientCertPEM, t.ClientCertFile)
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := pemContent("client key", t.ClientKeyPEM, t.ClientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if (clientCert == nil) != (clientKey == nil) {
+		return nil, fmt.Errorf("a client certificate and a client key must be configured together")
+	}
+	if clientCert != nil {
+		pair, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+	return config, nil
+}
+
+// pemContent returns the PEM content, reading it from the file when no content is given.
+func pemContent(name string, content string, file string) ([]byte, error) {
+	if content != "" {
+		return []byte(content), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s file: %w", name, err)
+	}
+	return data, nil
+}
@@ -19,6 +19,12 @@ type TopicConfigResponse struct {
 	} `json:"_embedded"`
 }
 
+type TopicConfigsResponse struct {
+	Embedded struct {
+		TopicConfigs []TopicConfigResponse `json:"stream_configs"`
+	} `json:"_embedded"`
+}
+
 type TopicConfigRequest struct {
 	Partitions         int                    `json:"partitions,omitempty"`
 	RetentionTime      int                    `json:"retentionTime,omitempty"`
@@ -1,28 +1,29 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
-	"time"
 )
 
-func (c *Client) ReadTopicConfig(id string) (*TopicConfigResponse, error) {
+func (c *Client) ReadTopicConfig(ctx context.Context, id string) (*TopicConfigResponse, error) {
 	o := TopicConfigResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/stream_configs/%s", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/stream_configs/%s", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 
-	keySchemaVersion, err := c.GetKeySchemaVersion(id)
+	keySchemaVersion, err := c.GetKeySchemaVersion(ctx, id)
 	if err == nil {
 		o.KeySchemaVersion = keySchemaVersion.Uid
 	} else if !errors.Is(err, NotFoundError) {
 		return nil, err
 	}
 
-	valueSchemaVersion, err := c.GetValueSchemaVersion(id)
+	valueSchemaVersion, err := c.GetValueSchemaVersion(ctx, id)
 	if err == nil {
 		o.ValueSchemaVersion = valueSchemaVersion.Uid
 	} else if !errors.Is(err, NotFoundError) {
@@ -32,18 +33,43 @@ func (c *Client) ReadTopicConfig(id string) (*TopicConfigResponse, error) {
 	return &o, nil
 }
 
-func (c *Client) CreateTopicConfig(topic TopicConfigRequest) (*TopicConfigResponse, error) {
+// ListTopicConfigs returns every topic config the user can see, without the schema versions ReadTopicConfig
+// looks up for each config.
+func (c *Client) ListTopicConfigs(ctx context.Context) (*TopicConfigsResponse, error) {
+	o := TopicConfigsResponse{}
+	err := c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/stream_configs", c.ApiURL), nil, &o)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (c *Client) CreateTopicConfig(ctx context.Context, topic TopicConfigRequest) (*TopicConfigResponse, error) {
 	o := TopicConfigResponse{}
 	marshal, err := json.Marshal(topic)
 	if err != nil {
 		return nil, err
 	}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/stream_configs", c.ApiURL), strings.NewReader(string(marshal)), nil, &o)
+	// The API rejects the config with a conflict while the topic is still being propagated to the Kafka cluster,
+	// so the request is retried on conflicts as well.
+	retryCtx, retries := withConflictRetries(ctx)
+	err = c.RequestAndMap(retryCtx, "POST", fmt.Sprintf("%s/stream_configs", c.ApiURL), strings.NewReader(string(marshal)), nil, &o)
+	if errors.Is(err, ConflictError) && retries.retriedServerError {
+		// The attempt that failed with a server error may have created the config after all,
+		// in which case the conflict is about the config of this request.
+		existing, findErr := c.findTopicConfig(ctx, linkUid(topic.Stream), linkUid(topic.Environment))
+		if findErr != nil {
+			return nil, findErr
+		}
+		if existing != nil {
+			o, err = *existing, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 	// Get the key schema versions for the topic config
-	keySchemaVersion, err := c.GetKeySchemaVersion(o.Uid)
+	keySchemaVersion, err := c.GetKeySchemaVersion(ctx, o.Uid)
 	if err == nil {
 		o.KeySchemaVersion = keySchemaVersion.Uid
 	} else if !errors.Is(err, NotFoundError) {
@@ -51,24 +77,28 @@ func (c *Client) CreateTopicConfig(topic TopicConfigRequest) (*TopicConfigRespon
 	}
 
 	// Get the value schema versions for the topic config
-	valueSchemaVersion, err := c.GetValueSchemaVersion(o.Uid)
+	valueSchemaVersion, err := c.GetValueSchemaVersion(ctx, o.Uid)
 	if err == nil {
 		o.ValueSchemaVersion = valueSchemaVersion.Uid
 	} else if !errors.Is(err, NotFoundError) {
 		return nil, err
 	}
-	time.Sleep(3 * time.Second) // ACL application can take significant time to apply in Kafka cluster for all the brokers, we have no control over how long it takes, especially with multiple topic configs
+	if err := c.waitForTopicConfigApplied(ctx, &o); err != nil {
+		return nil, err
+	}
 	return &o, nil
 }
 
-func (c *Client) UpdateTopicConfig(id string, topicRequest TopicConfigRequest) (*TopicConfigResponse, error) {
+func (c *Client) UpdateTopicConfig(ctx context.Context, id string, topicRequest TopicConfigRequest) (*TopicConfigResponse, error) {
 	o := TopicConfigResponse{}
 	marshal, err := json.Marshal(topicRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.RequestAndMap("PATCH", fmt.Sprintf("%s/stream_configs/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
+	// Like creation, the update is rejected while an earlier change of the topic is still propagating.
+	retryCtx, _ := withConflictRetries(ctx)
+	err = c.RequestAndMap(retryCtx, "PATCH", fmt.Sprintf("%s/stream_configs/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
 	if err != nil {
 		// If we get an UnprocessableEntity error, print a specific error message
 		if errors.Is(err, UnprocessableEntityError) {
@@ -78,7 +108,7 @@ func (c *Client) UpdateTopicConfig(id string, topicRequest TopicConfigRequest) (
 		}
 	}
 	// Get the key schema versions for the topic config
-	keySchemaVersion, err := c.GetKeySchemaVersion(o.Uid)
+	keySchemaVersion, err := c.GetKeySchemaVersion(ctx, o.Uid)
 	if err == nil {
 		o.KeySchemaVersion = keySchemaVersion.Uid
 	} else if !errors.Is(err, NotFoundError) {
@@ -86,54 +116,101 @@ func (c *Client) UpdateTopicConfig(id string, topicRequest TopicConfigRequest) (
 	}
 
 	// Get the value schema versions for the topic config
-	valueSchemaVersion, err := c.GetValueSchemaVersion(o.Uid)
+	valueSchemaVersion, err := c.GetValueSchemaVersion(ctx, o.Uid)
 	if err == nil {
 		o.ValueSchemaVersion = valueSchemaVersion.Uid
 	} else if !errors.Is(err, NotFoundError) {
 		return nil, err
 	}
-	time.Sleep(3 * time.Second) // ACL application can take significant time to apply in Kafka cluster for all the brokers, we have no control over how long it takes, especially with multiple topic configs
+	if err := c.waitForTopicConfigApplied(ctx, &o); err != nil {
+		return nil, err
+	}
 	return &o, nil
 }
 
-func (c *Client) DeleteTopicConfig(id string) error {
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/stream_configs/%v", c.ApiURL, id), nil, nil, nil)
+func (c *Client) DeleteTopicConfig(ctx context.Context, id string) error {
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/stream_configs/%v", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
-	time.Sleep(2 * time.Second) // To give time for Kafka to propagate changes
-	return nil
+	return c.waitForTopicConfigRemoval(ctx, id)
+}
+
+// waitForTopicConfigApplied waits until the platform applied the topic config to the Kafka cluster, instead of
+// assuming that it did so within a fixed time. The API only serves the access grants of a topic in an environment,
+// and so only accepts new grants, once the ACLs of its config are applied there; until then it rejects the search
+// with a conflict or does not find the topic config.
+func (c *Client) waitForTopicConfigApplied(ctx context.Context, config *TopicConfigResponse) error {
+	attributes := ApplicationAccessGrantAttributes{TopicId: config.Embedded.Stream.Uid, EnvironmentId: config.Embedded.Environment.Uid}
+	return c.waitFor(ctx, fmt.Sprintf("topic config %s to be applied", config.Uid), func(ctx context.Context) (bool, error) {
+		_, err := c.GetApplicationAccessGrantsByAttributes(ctx, attributes)
+		if errors.Is(err, ConflictError) || errors.Is(err, NotFoundError) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
+
+// findTopicConfig returns the config of the topic in the environment, or nil when there is none.
+func (c *Client) findTopicConfig(ctx context.Context, topic string, environment string) (*TopicConfigResponse, error) {
+	configs, err := c.ListTopicConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs.Embedded.TopicConfigs {
+		if config.Embedded.Stream.Uid == topic && config.Embedded.Environment.Uid == environment {
+			return &config, nil
+		}
+	}
+	return nil, nil
+}
+
+// linkUid returns the uid at the end of a link to a resource, such as the stream of a TopicConfigRequest.
+func linkUid(link string) string {
+	return path.Base(strings.TrimSuffix(link, "/"))
+}
+
+// waitForTopicConfigRemoval waits until the deleted topic config is no longer served by the API, instead of
+// assuming that the platform removed it from the Kafka cluster within a fixed time.
+func (c *Client) waitForTopicConfigRemoval(ctx context.Context, id string) error {
+	return c.waitFor(ctx, fmt.Sprintf("topic config %s to be removed", id), func(ctx context.Context) (bool, error) {
+		err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/stream_configs/%s", c.ApiURL, id), nil, nil, nil)
+		if errors.Is(err, NotFoundError) {
+			return true, nil
+		}
+		return false, err
+	})
 }
 
-func (c *Client) GetTopicConfigPermissions(topicConfigID string, permType string) ([]PermissionResponse, error) {
+func (c *Client) GetTopicConfigPermissions(ctx context.Context, topicConfigID string, permType string) ([]PermissionResponse, error) {
 	var perms []PermissionResponse
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/stream_configs/%s/permissions?type=%s", c.ApiURL, topicConfigID, permType), nil, nil, &perms)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/stream_configs/%s/permissions?type=%s", c.ApiURL, topicConfigID, permType), nil, nil, &perms)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get browse permissions of type '%s' for topic config with ID '%s': %w", permType, topicConfigID, err)
 	}
 	return perms, nil
 }
 
-func (c *Client) DeleteTopicConfigPermissions(topicConfigID string, request PermissionRequest) error {
+func (c *Client) DeleteTopicConfigPermissions(ctx context.Context, topicConfigID string, request PermissionRequest) error {
 	marshal, err := json.Marshal(request)
 	if err != nil {
 		return fmt.Errorf("failed to marshal browse permission request for topic config with ID '%s': %w", topicConfigID, err)
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("DELETE", fmt.Sprintf("%s/stream_configs/%s/permissions?type=browse", c.ApiURL, topicConfigID), strings.NewReader(string(marshal)), headers, nil)
+	err = c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/stream_configs/%s/permissions?type=browse", c.ApiURL, topicConfigID), strings.NewReader(string(marshal)), headers, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete browse permissions for topic config with ID '%s': %w", topicConfigID, err)
 	}
 	return nil
 }
 
-func (c *Client) AddTopicConfigPermissions(topicConfigID string, request PermissionRequest) error {
+func (c *Client) AddTopicConfigPermissions(ctx context.Context, topicConfigID string, request PermissionRequest) error {
 	marshal, err := json.Marshal(request)
 	if err != nil {
 		return fmt.Errorf("failed to marshal browse permission request for topic config with ID '%s': %w", topicConfigID, err)
 	}
 	headers := map[string]string{"Content-Type": "application/json"}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/stream_configs/%s/permissions", c.ApiURL, topicConfigID), strings.NewReader(string(marshal)), headers, nil)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/stream_configs/%s/permissions", c.ApiURL, topicConfigID), strings.NewReader(string(marshal)), headers, nil)
 	if err != nil {
 		return fmt.Errorf("failed to add browse permissions for topic config with ID '%s': %w", topicConfigID, err)
 	}
@@ -41,6 +41,12 @@ type TopicRequest struct {
 	Properties      map[string]interface{} `json:"properties,omitempty"`
 }
 
+type TopicsResponse struct {
+	Embedded struct {
+		Topics []TopicResponse `json:"streams"`
+	} `json:"_embedded"`
+}
+
 type TopicsByNameResponse struct {
 	Embedded struct {
 		Topics []struct {
@@ -1,58 +1,69 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
-func (c *Client) GetTopic(id string) (*TopicResponse, error) {
+func (c *Client) GetTopic(ctx context.Context, id string) (*TopicResponse, error) {
 	o := TopicResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/streams/%s", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/streams/%s", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) CreateTopic(topic TopicRequest) (*TopicResponse, error) {
+func (c *Client) CreateTopic(ctx context.Context, topic TopicRequest) (*TopicResponse, error) {
 	o := TopicResponse{}
 	marshal, err := json.Marshal(topic)
 	if err != nil {
 		return nil, err
 	}
-	err = c.RequestAndMap("POST", fmt.Sprintf("%s/streams", c.ApiURL), strings.NewReader(string(marshal)), nil, &o)
+	err = c.RequestAndMap(ctx, "POST", fmt.Sprintf("%s/streams", c.ApiURL), strings.NewReader(string(marshal)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) UpdateTopic(id string, TopicRequest TopicRequest) (*TopicResponse, error) {
+func (c *Client) UpdateTopic(ctx context.Context, id string, TopicRequest TopicRequest) (*TopicResponse, error) {
 	o := TopicResponse{}
 	marshal, err := json.Marshal(TopicRequest)
 	if err != nil {
 		return nil, err
 	}
-	err = c.RequestAndMap("PATCH", fmt.Sprintf("%s/streams/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
+	err = c.RequestAndMap(ctx, "PATCH", fmt.Sprintf("%s/streams/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) DeleteTopic(id string) error {
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/streams/%v", c.ApiURL, id), nil, nil, nil)
+func (c *Client) DeleteTopic(ctx context.Context, id string) error {
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/streams/%v", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) GetTopicByName(name string) (*TopicsByNameResponse, error) {
+// ListTopics returns every topic the user can see.
+func (c *Client) ListTopics(ctx context.Context) (*TopicsResponse, error) {
+	o := TopicsResponse{}
+	err := c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/streams", c.ApiURL), nil, &o)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (c *Client) GetTopicByName(ctx context.Context, name string) (*TopicsByNameResponse, error) {
 	o := TopicsByNameResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/streams/search/findByName?name=%s", c.ApiURL, url.QueryEscape(name)), nil, nil, &o)
+	err := c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/streams/search/findByName?name=%s", c.ApiURL, url.QueryEscape(name)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
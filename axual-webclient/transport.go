@@ -0,0 +1,80 @@
+package webclient
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig routes the requests of the Client through an HTTP proxy.
+type ProxyConfig struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128, used for both HTTP and HTTPS requests.
+	URL string
+	// NoProxy lists the hosts that are reached directly, with the semantics of the NO_PROXY environment
+	// variable: host names match their subdomains, and IP addresses, CIDR ranges and ports are supported.
+	// The NO_PROXY environment variable is used when it is empty.
+	NoProxy []string
+}
+
+// WithProxy routes the API and token requests through a proxy, instead of the proxy of the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+func WithProxy(config ProxyConfig) Option {
+	return func(c *Client) {
+		c.proxyConfig = config
+	}
+}
+
+// WithHeaders adds headers to every API and token request, e.g. a tenant header required by an API gateway.
+// Headers set by the Client itself, such as Authorization and Realm, are not replaced.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.headers = headers
+	}
+}
+
+// proxy returns the proxy function of the transport, or nil when the environment decides on the proxy.
+func (p ProxyConfig) proxy() func(*http.Request) (*url.URL, error) {
+	if p.URL == "" {
+		return nil
+	}
+	noProxy := strings.Join(p.NoProxy, ",")
+	if noProxy == "" {
+		noProxy = os.Getenv("NO_PROXY")
+		if noProxy == "" {
+			noProxy = os.Getenv("no_proxy")
+		}
+	}
+	config := httpproxy.Config{
+		HTTPProxy:  p.URL,
+		HTTPSProxy: p.URL,
+		NoProxy:    noProxy,
+	}
+	proxyFunc := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// setHeaders adds the headers to the request, keeping the headers that are already set.
+func setHeaders(header http.Header, headers map[string]string) {
+	for key, value := range headers {
+		if header.Get(key) == "" {
+			header.Set(key, value)
+		}
+	}
+}
+
+// headerTransport adds headers to the token requests, which are sent by golang.org/x/oauth2 for most grants.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	setHeaders(req.Header, t.headers)
+	return t.base.RoundTrip(req)
+}
@@ -1,25 +1,26 @@
 package webclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
-func (c *Client) GetUser(id string) (*UserResponse, error) {
+func (c *Client) GetUser(ctx context.Context, id string) (*UserResponse, error) {
 	o := UserResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/users/%v", c.ApiURL, id), nil, nil, &o)
+	err := c.RequestAndMap(ctx, "GET", fmt.Sprintf("%s/users/%v", c.ApiURL, id), nil, nil, &o)
 	if err != nil {
 		return nil, err
 	}
 	return &o, nil
 }
 
-func (c *Client) UpdateUser(id string, data UserRequest) (*UserResponse, error) {
+func (c *Client) UpdateUser(ctx context.Context, id string, data UserRequest) (*UserResponse, error) {
 	var roles []UserRole
 	roles = data.Roles
-	err := c.UpdateUserRoles(id, roles)
+	err := c.UpdateUserRoles(ctx, id, roles)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +31,7 @@ func (c *Client) UpdateUser(id string, data UserRequest) (*UserResponse, error)
 		return nil, err
 	}
 
-	err = c.RequestAndMap("PATCH", fmt.Sprintf("%s/users/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
+	err = c.RequestAndMap(ctx, "PATCH", fmt.Sprintf("%s/users/%v", c.ApiURL, id), strings.NewReader(string(marshal)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
@@ -38,15 +39,15 @@ func (c *Client) UpdateUser(id string, data UserRequest) (*UserResponse, error)
 	return &o, nil
 }
 
-func (c *Client) DeleteUser(id string) error {
-	err := c.RequestAndMap("DELETE", fmt.Sprintf("%s/users/%v", c.ApiURL, id), nil, nil, nil)
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	err := c.RequestAndMap(ctx, "DELETE", fmt.Sprintf("%s/users/%v", c.ApiURL, id), nil, nil, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) UpdateUserRoles(id string, data []UserRole) error {
+func (c *Client) UpdateUserRoles(ctx context.Context, id string, data []UserRole) error {
 	marshal, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -55,16 +56,16 @@ func (c *Client) UpdateUserRoles(id string, data []UserRole) error {
 	headers := make(map[string]string)
 	headers["Content-Type"] = "application/json"
 
-	err = c.RequestAndMap("PATCH", fmt.Sprintf("%s/users/%s/roles", c.ApiURL, id), strings.NewReader(string(marshal)), headers, nil)
+	err = c.RequestAndMap(ctx, "PATCH", fmt.Sprintf("%s/users/%s/roles", c.ApiURL, id), strings.NewReader(string(marshal)), headers, nil)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Client) FindUserByEmail(email string) (*UsersResponse, error) {
+func (c *Client) FindUserByEmail(ctx context.Context, email string) (*UsersResponse, error) {
 	o := UsersResponse{}
-	err := c.RequestAndMap("GET", fmt.Sprintf("%s/users/search/findByEmailAddress?email=%s", c.ApiURL, url.QueryEscape(email)), nil, nil, &o)
+	err := c.RequestAndMapAllPages(ctx, fmt.Sprintf("%s/users/search/findByEmailAddress?email=%s", c.ApiURL, url.QueryEscape(email)), nil, &o)
 	if err != nil {
 		return nil, err
 	}
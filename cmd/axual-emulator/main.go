@@ -0,0 +1,152 @@
+// Command axual-emulator serves an in-memory Axual self-service API and token endpoint on localhost, so Terraform
+// configurations using the provider can be applied without a platform. The state is kept in a JSON file between runs.
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"axual.com/terraform-provider-axual/internal/fakeapi"
+)
+
+// defaultSeed is the state of a new emulator: an instance, two users, a group and two environments,
+// of which development approves access grants right away.
+//
+//go:embed seed.json
+var defaultSeed []byte
+
+func main() {
+	var (
+		addr        string
+		statePath   string
+		seedPath    string
+		realm       string
+		username    string
+		password    string
+		version     string
+		autoApprove bool
+		reset       bool
+	)
+	flag.StringVar(&addr, "addr", "127.0.0.1:8080", "address to listen on")
+	flag.StringVar(&statePath, "state", "axual-emulator.json", "file the state is loaded from and saved to after every change; empty keeps it in memory")
+	flag.StringVar(&seedPath, "seed", "", "JSON file with the state to start from when there is no state file, instead of the built-in seed")
+	flag.StringVar(&realm, "realm", "axual", "realm shown in the example provider block")
+	flag.StringVar(&username, "username", "", "only accept this username in the password grant; any credentials are accepted when empty")
+	flag.StringVar(&password, "password", "", "password of -username")
+	flag.StringVar(&version, "platform-version", fakeapi.DefaultVersion, "Platform Manager version to report")
+	flag.BoolVar(&autoApprove, "auto-approve", false, "approve every access grant right away, not only those in environments with autoApproved")
+	flag.BoolVar(&reset, "reset", false, "start from the seed even if the state file exists")
+	flag.Parse()
+
+	state, err := loadState(statePath, seedPath, reset)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	options := []fakeapi.Option{fakeapi.WithVersion(version)}
+	if username != "" {
+		options = append(options, fakeapi.WithCredentials(username, password))
+	}
+	if autoApprove {
+		options = append(options, fakeapi.WithAutoApproval())
+	}
+	if statePath != "" {
+		options = append(options, fakeapi.WithOnChange(func(state *fakeapi.State) {
+			if err := saveState(statePath, state); err != nil {
+				log.Printf("unable to save the state: %s", err)
+			}
+		}))
+	}
+	server := fakeapi.New(state, options...)
+	if statePath != "" {
+		// Save the seed right away, so the uids of the seeded records can be looked up in the file.
+		if err := saveState(statePath, state); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	serverURL := "http://" + listener.Addr().String()
+	fmt.Printf(`Axual emulator listening on %s
+
+provider "axual" {
+  apiurl   = %q
+  authurl  = %q
+  realm    = %q
+  authmode = "keycloak"
+  clientid = "self-service"
+  username = %q
+  password = %q
+}
+`, serverURL, fakeapi.APIURL(serverURL), fakeapi.TokenURL(serverURL, realm), realm, valueOr(username, "admin@example.com"), valueOr(password, "admin"))
+	log.Fatal(http.Serve(listener, server))
+}
+
+// loadState returns the state in the state file, or the seed when there is none or reset is set.
+func loadState(statePath string, seedPath string, reset bool) (*fakeapi.State, error) {
+	data := defaultSeed
+	source := "the built-in seed"
+	if seedPath != "" {
+		seed, err := os.ReadFile(seedPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the seed: %w", err)
+		}
+		data, source = seed, seedPath
+	}
+	if statePath != "" && !reset {
+		saved, err := os.ReadFile(statePath)
+		switch {
+		case err == nil:
+			data, source = saved, statePath
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("unable to read the state: %w", err)
+		}
+	}
+
+	var state fakeapi.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid state in %s: %w", source, err)
+	}
+	log.Printf("loaded the state from %s", source)
+	return &state, nil
+}
+
+// saveState writes the state to a temporary file first, so an interrupted write does not corrupt the state file.
+func saveState(path string, state *fakeapi.State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func valueOr(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"axual.com/terraform-provider-axual/internal/fakeapi"
+)
+
+func TestLoadState(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.json")
+	if err := saveState(statePath, &fakeapi.State{Groups: []*fakeapi.Group{{Name: "Saved Team"}}}); err != nil {
+		t.Fatal(err)
+	}
+	seedPath := filepath.Join(dir, "seed.json")
+	if err := os.WriteFile(seedPath, []byte(`{"groups": [{"name": "Seeded Team"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc      string
+		statePath string
+		seedPath  string
+		reset     bool
+		wantGroup string
+	}{
+		{desc: "the built-in seed starts a new emulator", statePath: filepath.Join(dir, "missing.json"), wantGroup: "Local Team"},
+		{desc: "the seed file replaces the built-in seed", seedPath: seedPath, wantGroup: "Seeded Team"},
+		{desc: "the saved state is loaded", statePath: statePath, seedPath: seedPath, wantGroup: "Saved Team"},
+		{desc: "reset ignores the saved state", statePath: statePath, seedPath: seedPath, reset: true, wantGroup: "Seeded Team"},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			state, err := loadState(c.statePath, c.seedPath, c.reset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(state.Groups) != 1 || state.Groups[0].Name != c.wantGroup {
+				t.Errorf("expected group %s, got %+v", c.wantGroup, state.Groups)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package custom_validator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -88,3 +89,37 @@ func (v KsmlApplicationTypeValidator) ValidateString(ctx context.Context, req va
 		)
 	}
 }
+
+// DurationValidator validates that a string is a valid Go duration such as "30s" or "2m".
+type DurationValidator struct{}
+
+// Description returns the description of the validator.
+func (v DurationValidator) Description(_ context.Context) string {
+	return "Ensures that the value is a positive duration such as \"30s\" or \"2m\"."
+}
+
+// MarkdownDescription returns the markdown description of the validator.
+func (v DurationValidator) MarkdownDescription(_ context.Context) string {
+	return v.Description(context.Background())
+}
+
+// NewDurationValidator creates a new instance of DurationValidator.
+func NewDurationValidator() validator.String {
+	return DurationValidator{}
+}
+
+// ValidateString validates that the value parses as a positive duration.
+func (v DurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a positive duration such as \"30s\" or \"2m\", got: %s", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,150 @@
+package fakeapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func (s *Server) routeGrants() {
+	s.route("POST /application_access_grants", func(c *call) (interface{}, error) {
+		var request struct {
+			ApplicationId string `json:"applicationId"`
+			StreamId      string `json:"streamId"`
+			EnvironmentId string `json:"environmentId"`
+			AccessType    string `json:"accessType"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		grant := &Grant{
+			Record:      Record{Uid: newUID()},
+			Application: ref(request.ApplicationId),
+			Stream:      ref(request.StreamId),
+			Environment: ref(request.EnvironmentId),
+			AccessType:  request.AccessType,
+			Status:      "Pending",
+		}
+		if grant.AccessType != "Consumer" && grant.AccessType != "Producer" {
+			return nil, badRequest("Validation failed", fieldError{Field: "accessType", Message: "must be Consumer or Producer"})
+		}
+		if err := s.requireApplicationAndEnvironment(grant.Application, grant.Environment); err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(s.state.TopicConfigs, func(t *TopicConfig) bool {
+			return t.Stream == grant.Stream && t.Environment == grant.Environment
+		}) {
+			return nil, badRequest("Validation failed", fieldError{Field: "streamId", Message: fmt.Sprintf("stream %s is not configured in environment %s", grant.Stream, grant.Environment)})
+		}
+		for _, other := range s.state.Grants {
+			if other.Application == grant.Application && other.Stream == grant.Stream && other.Environment == grant.Environment &&
+				other.AccessType == grant.AccessType && (other.Status == "Pending" || other.Status == "Approved") {
+				return nil, conflict(fmt.Sprintf("Application access grant %s already exists with status %s", other.Uid, other.Status))
+			}
+		}
+		if environment, _ := find(s.state.Environments, grant.Environment); environment.AutoApproved || s.autoApproval {
+			grant.Status = "Approved"
+		}
+		s.state.Grants = append(s.state.Grants, grant)
+		return created{object{
+			"uid":         grant.Uid,
+			"status":      grant.Status,
+			"environment": object{"id": grant.Environment},
+		}}, nil
+	})
+	s.route("GET /application_access_grants/{id}", func(c *call) (interface{}, error) {
+		grant, ok := find(s.state.Grants, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application access grant", c.PathValue("id"))
+		}
+		return s.grantView(c, grant), nil
+	})
+	// Approving, cancelling and denying a grant change its status, only from the statuses it is linked from.
+	s.route("PUT /application_access_grants/{id}", func(c *call) (interface{}, error) {
+		return s.changeGrant(c, "Approved", "Pending")
+	})
+	s.route("DELETE /application_access_grants/{id}", func(c *call) (interface{}, error) {
+		return s.changeGrant(c, "Cancelled", "Pending")
+	})
+	s.route("POST /application_access_grants/{id}/deny", func(c *call) (interface{}, error) {
+		var request struct {
+			Reason string `json:"reason"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		grant, ok := find(s.state.Grants, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application access grant", c.PathValue("id"))
+		}
+		status := "Rejected"
+		if grant.Status == "Approved" {
+			status = "Revoked"
+		}
+		if _, err := s.changeGrant(c, status, "Pending", "Approved"); err != nil {
+			return nil, err
+		}
+		grant.Comment = request.Reason
+		return nil, nil
+	})
+	s.route("GET /application_access_grants/search/findByAttributes", func(c *call) (interface{}, error) {
+		query := c.URL.Query()
+		var statuses []string
+		if query.Get("statuses") != "" {
+			statuses = strings.Split(strings.ToLower(query.Get("statuses")), ",")
+		}
+		views := []interface{}{}
+		for _, grant := range s.state.Grants {
+			if matches(query.Get("applicationId"), grant.Application) && matches(query.Get("streamId"), grant.Stream) &&
+				matches(query.Get("environmentId"), grant.Environment) && matches(query.Get("accessType"), grant.AccessType) &&
+				(statuses == nil || slices.Contains(statuses, strings.ToLower(grant.Status))) {
+				view := s.grantView(c, grant)
+				delete(view, "_links")
+				views = append(views, view)
+			}
+		}
+		return s.page(c, "applicationAccessGrantResponses", views), nil
+	})
+}
+
+// matches reports whether the value matches an optional search parameter.
+func matches(parameter string, value string) bool {
+	return parameter == "" || ref(parameter) == value
+}
+
+func (s *Server) changeGrant(c *call, status string, from ...string) (interface{}, error) {
+	grant, ok := find(s.state.Grants, c.PathValue("id"))
+	if !ok {
+		return nil, notFound("application access grant", c.PathValue("id"))
+	}
+	if !slices.Contains(from, grant.Status) {
+		return nil, badRequest(fmt.Sprintf("Application access grant %s is %s and can not become %s", grant.Uid, grant.Status, status))
+	}
+	grant.Status = status
+	return nil, nil
+}
+
+// grantView returns the grant with the links of the actions its status allows.
+func (s *Server) grantView(c *call, grant *Grant) object {
+	self := c.href("application_access_grants", grant.Uid)
+	links := object{}
+	switch grant.Status {
+	case "Pending":
+		links["approve"] = link(self)
+		links["cancel"] = link(self)
+		links["deny"] = link(self + "/deny")
+	case "Approved":
+		links["revoke"] = link(self + "/deny")
+	}
+	return c.resource("application_access_grants", grant.Uid, object{
+		"status":     grant.Status,
+		"accessType": grant.AccessType,
+		"comment":    nullable(grant.Comment),
+		"_embedded": object{
+			"application": s.applicationRef(c, grant.Application),
+			"environment": s.environmentRef(c, grant.Environment),
+			"stream":      c.resource("streams", grant.Stream, object{}),
+		},
+		"_links": links,
+	})
+}
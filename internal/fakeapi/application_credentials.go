@@ -0,0 +1,116 @@
+package fakeapi
+
+import "strings"
+
+func (s *Server) routeCredentials() {
+	s.route("POST /application_authentications", func(c *call) (interface{}, error) {
+		var request struct {
+			ApplicationId string `json:"applicationId"`
+			EnvironmentId string `json:"environmentId"`
+			Target        string `json:"target"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		credential := &Credential{
+			Record:      Record{Uid: newUID()},
+			Application: ref(request.ApplicationId),
+			Environment: ref(request.EnvironmentId),
+			Target:      request.Target,
+			Password:    newUID(),
+		}
+		if credential.Target == "" {
+			credential.Target = "KAFKA"
+		}
+		if credential.Target != "KAFKA" && credential.Target != "SCHEMA_REGISTRY" {
+			return nil, badRequest("Validation failed", fieldError{Field: "target", Message: "must be KAFKA or SCHEMA_REGISTRY"})
+		}
+		if err := s.requireApplicationAndEnvironment(credential.Application, credential.Environment); err != nil {
+			return nil, err
+		}
+		application, _ := find(s.state.Applications, credential.Application)
+		credential.Username = strings.ToLower(application.ShortName) + "-" + credential.Uid[:8]
+		s.state.Credentials = append(s.state.Credentials, credential)
+		return []interface{}{object{
+			"authData": object{
+				"username": credential.Username,
+				"password": credential.Password,
+				"provider": credentialTypes(credential)[0],
+				"clusters": s.clusters(credential),
+			},
+		}}, nil
+	})
+	s.route("DELETE /application_authentications", func(c *call) (interface{}, error) {
+		var request struct {
+			ApplicationId string `json:"applicationId"`
+			EnvironmentId string `json:"environmentId"`
+			Configs       struct {
+				Username string `json:"username"`
+			} `json:"configs"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		for _, credential := range s.state.Credentials {
+			if credential.Application == ref(request.ApplicationId) && credential.Environment == ref(request.EnvironmentId) &&
+				credential.Username == request.Configs.Username {
+				s.state.Credentials = remove(s.state.Credentials, credential.Uid)
+				return nil, nil
+			}
+		}
+		return nil, notFound("application credential", request.Configs.Username)
+	})
+	s.route("GET /application_credentials/{id}", func(c *call) (interface{}, error) {
+		credential, ok := find(s.state.Credentials, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application credential", c.PathValue("id"))
+		}
+		return s.credentialView(credential), nil
+	})
+	// Unlike the other searches, this one responds with a plain list.
+	s.route("GET /application_credentials/search/findByApplicationIdAndEnvironmentId", func(c *call) (interface{}, error) {
+		application := ref(c.URL.Query().Get("applicationId"))
+		environment := ref(c.URL.Query().Get("environmentId"))
+		views := []interface{}{}
+		for _, credential := range s.state.Credentials {
+			if credential.Application == application && credential.Environment == environment {
+				views = append(views, s.credentialView(credential))
+			}
+		}
+		return views, nil
+	})
+}
+
+// credentialTypes returns the authentication types of the credential, which follow from its target.
+func credentialTypes(credential *Credential) []string {
+	if credential.Target == "SCHEMA_REGISTRY" {
+		return []string{"SCHEMA_REGISTRY_BASIC_AUTH"}
+	}
+	return []string{"SCRAM_SHA_512"}
+}
+
+// clusters returns the clusters the credential is applied to: the instance of its environment.
+func (s *Server) clusters(credential *Credential) string {
+	if environment, ok := find(s.state.Environments, credential.Environment); ok {
+		if instance, ok := find(s.state.Instances, environment.Instance); ok {
+			return instance.ShortName
+		}
+	}
+	return ""
+}
+
+func (s *Server) credentialView(credential *Credential) object {
+	types := []interface{}{}
+	for _, t := range credentialTypes(credential) {
+		types = append(types, object{"type": t})
+	}
+	return object{
+		"id":          credential.Uid,
+		"application": object{"id": credential.Application},
+		"environment": object{"id": credential.Environment},
+		"username":    credential.Username,
+		"types":       types,
+		"description": nil,
+		"metadata":    object{"clusters": s.clusters(credential)},
+	}
+}
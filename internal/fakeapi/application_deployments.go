@@ -0,0 +1,149 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// invalidDeploymentState is the message of the platform for an operation the state of a deployment does not allow.
+const invalidDeploymentState = "Invalid action for this state of deployment"
+
+func (s *Server) routeDeployments() {
+	s.route("POST /application_deployments", func(c *call) (interface{}, error) {
+		var request struct {
+			Application string            `json:"application"`
+			Environment string            `json:"environment"`
+			Configs     map[string]string `json:"configs"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		deployment := &Deployment{
+			Record:      Record{Uid: newUID()},
+			Application: ref(request.Application),
+			Environment: ref(request.Environment),
+			Configs:     request.Configs,
+			State:       "Undeployed",
+		}
+		if err := s.requireApplicationAndEnvironment(deployment.Application, deployment.Environment); err != nil {
+			return nil, err
+		}
+		for _, other := range s.state.Deployments {
+			if other.Application == deployment.Application && other.Environment == deployment.Environment {
+				return nil, conflict(fmt.Sprintf("Application is already deployed in the environment with deployment %s", other.Uid))
+			}
+		}
+		s.state.Deployments = append(s.state.Deployments, deployment)
+		return created{deployment.Uid}, nil
+	})
+	s.route("GET /application_deployments/{id}", func(c *call) (interface{}, error) {
+		deployment, ok := find(s.state.Deployments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application deployment", c.PathValue("id"))
+		}
+		return s.deploymentView(c, deployment), nil
+	})
+	s.route("PUT /application_deployments/{id}", func(c *call) (interface{}, error) {
+		deployment, ok := find(s.state.Deployments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application deployment", c.PathValue("id"))
+		}
+		var request struct {
+			Configs map[string]string `json:"configs"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		deployment.Configs = request.Configs
+		return s.deploymentView(c, deployment), nil
+	})
+	s.route("DELETE /application_deployments/{id}", func(c *call) (interface{}, error) {
+		deployment, ok := find(s.state.Deployments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application deployment", c.PathValue("id"))
+		}
+		if deployment.State == "Running" {
+			return nil, badRequest("A running deployment can not be deleted, stop it first")
+		}
+		s.state.Deployments = remove(s.state.Deployments, deployment.Uid)
+		return nil, nil
+	})
+	s.route("PUT /application_deployments/{id}/operation", func(c *call) (interface{}, error) {
+		deployment, ok := find(s.state.Deployments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application deployment", c.PathValue("id"))
+		}
+		switch action := c.URL.Query().Get("action"); action {
+		case "START":
+			if deployment.State == "Running" {
+				return nil, badRequest(invalidDeploymentState)
+			}
+			if err := s.requireActivePrincipal(deployment); err != nil {
+				return nil, err
+			}
+			deployment.State = "Running"
+		case "STOP":
+			if deployment.State != "Running" {
+				return nil, badRequest(invalidDeploymentState)
+			}
+			deployment.State = "Stopped"
+		default:
+			return nil, badRequest(fmt.Sprintf("Unknown action %q", action))
+		}
+		return nil, nil
+	})
+	s.route("GET /application_deployments/{id}/status", func(c *call) (interface{}, error) {
+		deployment, ok := find(s.state.Deployments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application deployment", c.PathValue("id"))
+		}
+		connectorState, ksmlStatus := "Stopped", "Undeployed"
+		if deployment.State == "Running" {
+			connectorState, ksmlStatus = "Running", "Running"
+		}
+		return object{
+			"connectorState": object{"state": connectorState},
+			"ksmlStatus":     object{"status": ksmlStatus},
+		}, nil
+	})
+	s.route("GET /application_deployments/search/findByApplicationAndEnvironment", func(c *call) (interface{}, error) {
+		application := ref(c.URL.Query().Get("application"))
+		environment := ref(c.URL.Query().Get("environment"))
+		views := []interface{}{}
+		for _, deployment := range s.state.Deployments {
+			if deployment.Application == application && deployment.Environment == environment {
+				views = append(views, s.deploymentView(c, deployment))
+			}
+		}
+		return s.page(c, "application_deployments", views), nil
+	})
+}
+
+// requireActivePrincipal returns an error when a Connector is started without an active principal.
+func (s *Server) requireActivePrincipal(deployment *Deployment) error {
+	application, ok := find(s.state.Applications, deployment.Application)
+	if !ok || application.ApplicationType != "Connector" {
+		return nil
+	}
+	for _, principal := range s.state.Principals {
+		if principal.Application == deployment.Application && principal.Environment == deployment.Environment && principal.Active {
+			return nil
+		}
+	}
+	return &httpError{status: http.StatusBadRequest, message: "The application has no active principal in the environment"}
+}
+
+func (s *Server) deploymentView(c *call, deployment *Deployment) object {
+	configs := []interface{}{}
+	for key, value := range deployment.Configs {
+		configs = append(configs, object{"configKey": key, "configValue": value})
+	}
+	return c.resource("application_deployments", deployment.Uid, object{
+		"state":   deployment.State,
+		"configs": configs,
+		"_embedded": object{
+			"application": s.applicationRef(c, deployment.Application),
+			"environment": s.environmentRef(c, deployment.Environment),
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package fakeapi
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+func (s *Server) routePrincipals() {
+	s.route("POST /application_principals", func(c *call) (interface{}, error) {
+		var requests []struct {
+			Principal   string `json:"principal"`
+			PrivateKey  string `json:"privateKey"`
+			Application string `json:"application"`
+			Environment string `json:"environment"`
+			Custom      bool   `json:"custom"`
+		}
+		if err := c.decode(&requests); err != nil {
+			return nil, err
+		}
+		if len(requests) == 0 {
+			return nil, badRequest("At least one principal is required")
+		}
+		var principals []*Principal
+		for _, request := range requests {
+			principal := &Principal{
+				Record:      Record{Uid: newUID()},
+				Application: ref(request.Application),
+				Environment: ref(request.Environment),
+				Principal:   request.Principal,
+				Type:        "OAUTH",
+			}
+			if err := s.requireApplicationAndEnvironment(principal.Application, principal.Environment); err != nil {
+				return nil, err
+			}
+			if !request.Custom {
+				subject, err := certificateSubject(request.Principal)
+				if err != nil {
+					return nil, badRequest("Invalid certificate: "+err.Error(), fieldError{Field: "principal", Message: err.Error()})
+				}
+				principal.Principal = subject
+				principal.ApplicationPem = strings.TrimSpace(request.Principal)
+				principal.Type = "SSL"
+			}
+			principals = append(principals, principal)
+		}
+		// New principals are inactive, the platform does not activate them by itself.
+		s.state.Principals = append(s.state.Principals, principals...)
+		return created{principals[0].Uid}, nil
+	})
+	s.route("GET /application_principals/{id}", func(c *call) (interface{}, error) {
+		principal, ok := find(s.state.Principals, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application principal", c.PathValue("id"))
+		}
+		return s.principalView(c, principal), nil
+	})
+	s.route("DELETE /application_principals/{id}", func(c *call) (interface{}, error) {
+		if _, ok := find(s.state.Principals, c.PathValue("id")); !ok {
+			return nil, notFound("application principal", c.PathValue("id"))
+		}
+		s.state.Principals = remove(s.state.Principals, c.PathValue("id"))
+		return nil, nil
+	})
+	s.route("POST /application_authentications/{id}/activate", func(c *call) (interface{}, error) {
+		principal, ok := find(s.state.Principals, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application principal", c.PathValue("id"))
+		}
+		// Activating a principal deactivates the other principals of the application in the environment.
+		for _, other := range s.state.Principals {
+			if other.Application == principal.Application && other.Environment == principal.Environment {
+				other.Active = other == principal
+			}
+		}
+		return nil, nil
+	})
+	s.route("GET /application_principals/search/findByApplicationAndEnvironment", func(c *call) (interface{}, error) {
+		application := ref(c.URL.Query().Get("application"))
+		environment := ref(c.URL.Query().Get("environment"))
+		views := []interface{}{}
+		for _, principal := range s.state.Principals {
+			if principal.Application == application && principal.Environment == environment {
+				views = append(views, s.principalView(c, principal))
+			}
+		}
+		return s.page(c, "application_principals", views), nil
+	})
+}
+
+// certificateSubject returns the subject of the first certificate of the PEM chain, which is the principal of SSL.
+func certificateSubject(chain string) (string, error) {
+	block, _ := pem.Decode([]byte(chain))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("no PEM certificate found")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	return certificate.Subject.String(), nil
+}
+
+func (s *Server) principalView(c *call, principal *Principal) object {
+	return c.resource("application_principals", principal.Uid, object{
+		"principal":      principal.Principal,
+		"applicationPem": nullable(principal.ApplicationPem),
+		"type":           principal.Type,
+		"active":         principal.Active,
+		"_embedded": object{
+			"application": s.applicationRef(c, principal.Application),
+			"environment": s.environmentRef(c, principal.Environment),
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *Server) routeApplications() {
+	s.route("GET /applications", func(c *call) (interface{}, error) {
+		views := []interface{}{}
+		for _, application := range s.state.Applications {
+			views = append(views, s.applicationView(c, application))
+		}
+		return s.page(c, "applications", views), nil
+	})
+	s.route("POST /applications", func(c *call) (interface{}, error) {
+		application := &Application{Record: Record{Uid: newUID()}}
+		if err := s.applyApplication(c, application); err != nil {
+			return nil, err
+		}
+		s.state.Applications = append(s.state.Applications, application)
+		return created{s.applicationView(c, application)}, nil
+	})
+	s.route("GET /applications/{id}", func(c *call) (interface{}, error) {
+		application, ok := find(s.state.Applications, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application", c.PathValue("id"))
+		}
+		return s.applicationView(c, application), nil
+	})
+	s.route("PATCH /applications/{id}", func(c *call) (interface{}, error) {
+		application, ok := find(s.state.Applications, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("application", c.PathValue("id"))
+		}
+		updated := *application
+		if err := s.applyApplication(c, &updated); err != nil {
+			return nil, err
+		}
+		*application = updated
+		return s.applicationView(c, application), nil
+	})
+	s.route("DELETE /applications/{id}", func(c *call) (interface{}, error) {
+		uid := c.PathValue("id")
+		if _, ok := find(s.state.Applications, uid); !ok {
+			return nil, notFound("application", uid)
+		}
+		for _, deployment := range s.state.Deployments {
+			if deployment.Application == uid {
+				return nil, conflict(fmt.Sprintf("Application %s can not be deleted, it has deployment %s", uid, deployment.Uid))
+			}
+		}
+		s.state.Applications = remove(s.state.Applications, uid)
+		return nil, nil
+	})
+	s.route("GET /applications/search/findByName", func(c *call) (interface{}, error) {
+		return s.findApplication(c, func(a *Application) bool { return a.Name == c.URL.Query().Get("name") })
+	})
+	s.route("GET /applications/search/findByShortName", func(c *call) (interface{}, error) {
+		return s.findApplication(c, func(a *Application) bool { return a.ShortName == c.URL.Query().Get("shortName") })
+	})
+}
+
+// findApplication returns the single application matching, like the findByName and findByShortName searches.
+func (s *Server) findApplication(c *call, match func(*Application) bool) (interface{}, error) {
+	applications := filter(s.state.Applications, match)
+	if len(applications) == 0 {
+		return nil, &httpError{status: http.StatusNotFound, message: "No application found"}
+	}
+	return s.applicationView(c, applications[0]), nil
+}
+
+// applyApplication applies the request to the application and validates the result.
+func (s *Server) applyApplication(c *call, application *Application) error {
+	if err := apply(c.body, application); err != nil {
+		return err
+	}
+	application.Owners = ref(application.Owners)
+	application.Viewers = refs(application.Viewers)
+	err := required(map[string]string{
+		"name":            application.Name,
+		"shortName":       application.ShortName,
+		"applicationId":   application.ApplicationId,
+		"applicationType": application.ApplicationType,
+		"owners":          application.Owners,
+	})
+	if err != nil {
+		return err
+	}
+	for _, other := range s.state.Applications {
+		if other.Uid == application.Uid {
+			continue
+		}
+		for field, clash := range map[string]bool{
+			"name":          other.Name == application.Name,
+			"shortName":     other.ShortName == application.ShortName,
+			"applicationId": other.ApplicationId == application.ApplicationId,
+		} {
+			if clash {
+				return conflict(fmt.Sprintf("Application %s already exists", field), fieldError{Field: field, Message: "must be unique"})
+			}
+		}
+	}
+	return s.requireGroup("owners", application.Owners)
+}
+
+func (s *Server) applicationView(c *call, application *Application) object {
+	return c.resource("applications", application.Uid, object{
+		"name":             application.Name,
+		"shortName":        application.ShortName,
+		"applicationId":    application.ApplicationId,
+		"description":      nullable(application.Description),
+		"applicationType":  application.ApplicationType,
+		"type":             nullable(application.Type),
+		"applicationClass": nullable(application.ApplicationClass),
+		"visibility":       application.Visibility,
+		"owners":           s.groupRef(c, application.Owners),
+		"_embedded": object{
+			"viewers": s.groupRefs(c, application.Viewers),
+		},
+	})
+}
+
+// applicationRef returns the embedded reference to the application.
+func (s *Server) applicationRef(c *call, uid string) object {
+	fields := object{}
+	if application, ok := find(s.state.Applications, uid); ok {
+		fields["name"] = application.Name
+		fields["shortName"] = application.ShortName
+		fields["applicationType"] = application.ApplicationType
+	}
+	return c.resource("applications", uid, fields)
+}
+
+// environmentRef returns the embedded reference to the environment.
+func (s *Server) environmentRef(c *call, uid string) object {
+	fields := object{}
+	if environment, ok := find(s.state.Environments, uid); ok {
+		fields["name"] = environment.Name
+		fields["shortName"] = environment.ShortName
+	}
+	return c.resource("environments", uid, fields)
+}
+
+// requireApplicationAndEnvironment returns a validation error when the application or environment does not exist.
+func (s *Server) requireApplicationAndEnvironment(application string, environment string) error {
+	if _, ok := find(s.state.Applications, application); !ok {
+		return badRequest("Validation failed", fieldError{Field: "application", Message: fmt.Sprintf("application %s does not exist", application)})
+	}
+	if _, ok := find(s.state.Environments, environment); !ok {
+		return badRequest("Validation failed", fieldError{Field: "environment", Message: fmt.Sprintf("environment %s does not exist", environment)})
+	}
+	return nil
+}
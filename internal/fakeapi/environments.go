@@ -0,0 +1,150 @@
+package fakeapi
+
+import "fmt"
+
+func (s *Server) routeEnvironments() {
+	list := func(c *call) (interface{}, error) {
+		return s.page(c, "environments", s.environmentViews(c, s.state.Environments)), nil
+	}
+	s.route("GET /environments", list)
+	s.route("GET /environments/{$}", list)
+	s.route("POST /environments", func(c *call) (interface{}, error) {
+		environment := &Environment{Record: Record{Uid: newUID()}}
+		if err := s.applyEnvironment(c, environment); err != nil {
+			return nil, err
+		}
+		s.state.Environments = append(s.state.Environments, environment)
+		return created{s.environmentView(c, environment)}, nil
+	})
+	s.route("GET /environments/{id}", func(c *call) (interface{}, error) {
+		environment, ok := find(s.state.Environments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("environment", c.PathValue("id"))
+		}
+		return s.environmentView(c, environment), nil
+	})
+	s.route("PATCH /environments/{id}", func(c *call) (interface{}, error) {
+		environment, ok := find(s.state.Environments, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("environment", c.PathValue("id"))
+		}
+		updated := *environment
+		updated.Properties = copyMap(environment.Properties)
+		updated.Settings = copyMap(environment.Settings)
+		if err := s.applyEnvironment(c, &updated); err != nil {
+			return nil, err
+		}
+		*environment = updated
+		return s.environmentView(c, environment), nil
+	})
+	s.route("DELETE /environments/{id}", func(c *call) (interface{}, error) {
+		uid := c.PathValue("id")
+		if _, ok := find(s.state.Environments, uid); !ok {
+			return nil, notFound("environment", uid)
+		}
+		for _, config := range s.state.TopicConfigs {
+			if config.Environment == uid {
+				return nil, conflict(fmt.Sprintf("Environment %s can not be deleted, topic config %s is deployed on it", uid, config.Uid))
+			}
+		}
+		s.state.Environments = remove(s.state.Environments, uid)
+		return nil, nil
+	})
+	s.route("GET /environments/search/findByName", func(c *call) (interface{}, error) {
+		name := c.URL.Query().Get("name")
+		environments := filter(s.state.Environments, func(e *Environment) bool { return e.Name == name })
+		return s.page(c, "environments", s.environmentViews(c, environments)), nil
+	})
+	s.route("GET /environments/search/findByShortName", func(c *call) (interface{}, error) {
+		shortName := c.URL.Query().Get("shortName")
+		environments := filter(s.state.Environments, func(e *Environment) bool { return e.ShortName == shortName })
+		return s.page(c, "environments", s.environmentViews(c, environments)), nil
+	})
+}
+
+// applyEnvironment applies the request to the environment and validates the result.
+func (s *Server) applyEnvironment(c *call, environment *Environment) error {
+	if err := apply(c.body, environment); err != nil {
+		return err
+	}
+	environment.Instance = ref(environment.Instance)
+	environment.Owners = ref(environment.Owners)
+	environment.Viewers = refs(environment.Viewers)
+	err := required(map[string]string{
+		"name":      environment.Name,
+		"shortName": environment.ShortName,
+		"instance":  environment.Instance,
+		"owners":    environment.Owners,
+	})
+	if err != nil {
+		return err
+	}
+	for _, other := range s.state.Environments {
+		if other.Uid == environment.Uid {
+			continue
+		}
+		if other.Name == environment.Name {
+			return conflict("Environment name already exists", fieldError{Field: "name", Message: "must be unique"})
+		}
+		if other.ShortName == environment.ShortName {
+			return conflict("Environment short name already exists", fieldError{Field: "shortName", Message: "must be unique"})
+		}
+	}
+	if _, ok := find(s.state.Instances, environment.Instance); !ok {
+		return badRequest("Validation failed", fieldError{Field: "instance", Message: fmt.Sprintf("instance %s does not exist", environment.Instance)})
+	}
+	return s.requireGroup("owners", environment.Owners)
+}
+
+func (s *Server) environmentViews(c *call, environments []*Environment) []interface{} {
+	views := []interface{}{}
+	for _, environment := range environments {
+		views = append(views, s.environmentView(c, environment))
+	}
+	return views
+}
+
+func (s *Server) environmentView(c *call, environment *Environment) object {
+	instance := object{}
+	if i, ok := find(s.state.Instances, environment.Instance); ok {
+		instance = instanceView(c, i)
+	}
+	return c.resource("environments", environment.Uid, object{
+		"name":                environment.Name,
+		"shortName":           environment.ShortName,
+		"description":         nullable(environment.Description),
+		"color":               environment.Color,
+		"authorizationIssuer": environment.AuthorizationIssuer,
+		"visibility":          environment.Visibility,
+		"retentionTime":       environment.RetentionTime,
+		"partitions":          environment.Partitions,
+		"private":             environment.Visibility == "Private",
+		"autoApproved":        environment.AutoApproved,
+		"properties":          nonNilMap(environment.Properties),
+		"settings":            nonNilMap(environment.Settings),
+		"_embedded": object{
+			"instance": instance,
+			"owners":   s.groupRef(c, environment.Owners),
+			"viewers":  s.groupRefs(c, environment.Viewers),
+		},
+	})
+}
+
+// copyMap copies properties before they are changed by a request that may be rejected.
+func copyMap(properties map[string]interface{}) map[string]interface{} {
+	if properties == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		copied[key] = value
+	}
+	return copied
+}
+
+func nonNilMap(properties map[string]interface{}) map[string]interface{} {
+	if properties == nil {
+		return map[string]interface{}{}
+	}
+	return properties
+}
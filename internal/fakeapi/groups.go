@@ -0,0 +1,156 @@
+package fakeapi
+
+import "fmt"
+
+func (s *Server) routeGroups() {
+	s.route("GET /groups", func(c *call) (interface{}, error) {
+		return s.page(c, "groups", groupViews(c, s.state.Groups)), nil
+	})
+	s.route("POST /groups", func(c *call) (interface{}, error) {
+		group := &Group{Record: Record{Uid: newUID()}}
+		if err := s.applyGroup(c, group); err != nil {
+			return nil, err
+		}
+		s.state.Groups = append(s.state.Groups, group)
+		return created{groupView(c, group)}, nil
+	})
+	s.route("GET /groups/{id}", func(c *call) (interface{}, error) {
+		group, ok := find(s.state.Groups, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("group", c.PathValue("id"))
+		}
+		return groupView(c, group), nil
+	})
+	s.route("PATCH /groups/{id}", func(c *call) (interface{}, error) {
+		group, ok := find(s.state.Groups, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("group", c.PathValue("id"))
+		}
+		updated := *group
+		if err := s.applyGroup(c, &updated); err != nil {
+			return nil, err
+		}
+		*group = updated
+		return groupView(c, group), nil
+	})
+	s.route("DELETE /groups/{id}", func(c *call) (interface{}, error) {
+		uid := c.PathValue("id")
+		if _, ok := find(s.state.Groups, uid); !ok {
+			return nil, notFound("group", uid)
+		}
+		if owned := s.ownedBy(uid); owned != "" {
+			return nil, conflict(fmt.Sprintf("Group %s can not be deleted, it owns %s", uid, owned))
+		}
+		s.state.Groups = remove(s.state.Groups, uid)
+		return nil, nil
+	})
+	s.route("GET /groups/search/findByName", func(c *call) (interface{}, error) {
+		name := c.URL.Query().Get("name")
+		groups := filter(s.state.Groups, func(g *Group) bool { return g.Name == name })
+		return s.page(c, "groups", groupViews(c, groups)), nil
+	})
+}
+
+// applyGroup applies the request to the group and validates the result.
+func (s *Server) applyGroup(c *call, group *Group) error {
+	if err := apply(c.body, group); err != nil {
+		return err
+	}
+	group.Members = refs(group.Members)
+	group.Managers = refs(group.Managers)
+	if err := required(map[string]string{"name": group.Name}); err != nil {
+		return err
+	}
+	for _, other := range s.state.Groups {
+		if other.Uid != group.Uid && other.Name == group.Name {
+			return conflict("Group name already exists", fieldError{Field: "name", Message: "must be unique"})
+		}
+	}
+	for _, member := range group.Members {
+		if _, ok := find(s.state.Users, member); !ok {
+			return badRequest("Validation failed", fieldError{Field: "members", Message: fmt.Sprintf("user %s does not exist", member)})
+		}
+	}
+	return nil
+}
+
+// ownedBy describes a record owned by the group, or returns an empty string.
+func (s *Server) ownedBy(group string) string {
+	for _, environment := range s.state.Environments {
+		if environment.Owners == group {
+			return "environment " + environment.Name
+		}
+	}
+	for _, topic := range s.state.Topics {
+		if topic.Owners == group {
+			return "topic " + topic.Name
+		}
+	}
+	for _, application := range s.state.Applications {
+		if application.Owners == group {
+			return "application " + application.Name
+		}
+	}
+	return ""
+}
+
+func groupViews(c *call, groups []*Group) []interface{} {
+	views := []interface{}{}
+	for _, group := range groups {
+		views = append(views, groupView(c, group))
+	}
+	return views
+}
+
+func groupView(c *call, group *Group) object {
+	var email interface{}
+	if group.EmailAddress != "" {
+		email = object{"email": group.EmailAddress}
+	}
+	return c.resource("groups", group.Uid, object{
+		"name":         group.Name,
+		"emailAddress": email,
+		"phoneNumber":  nullable(group.PhoneNumber),
+		"_embedded": object{
+			"members":  uidViews(c, "users", group.Members),
+			"managers": uidViews(c, "groups", group.Managers),
+		},
+	})
+}
+
+// uidViews returns the embedded references to records of the collection.
+func uidViews(c *call, collection string, uids []string) []interface{} {
+	views := []interface{}{}
+	for _, uid := range uids {
+		views = append(views, c.resource(collection, uid, object{}))
+	}
+	return views
+}
+
+// groupRef returns the embedded reference to the group, or nil.
+func (s *Server) groupRef(c *call, uid string) interface{} {
+	if uid == "" {
+		return nil
+	}
+	fields := object{}
+	if group, ok := find(s.state.Groups, uid); ok {
+		fields["name"] = group.Name
+	}
+	return c.resource("groups", uid, fields)
+}
+
+func (s *Server) groupRefs(c *call, uids []string) []interface{} {
+	views := []interface{}{}
+	for _, uid := range uids {
+		views = append(views, s.groupRef(c, uid))
+	}
+	return views
+}
+
+// requireGroup returns a validation error of the field when the group does not exist.
+func (s *Server) requireGroup(field string, uid string) error {
+	if _, ok := find(s.state.Groups, uid); !ok {
+		return badRequest("Validation failed", fieldError{Field: field, Message: fmt.Sprintf("group %s does not exist", uid)})
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package fakeapi
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// object is a JSON object of a response.
+type object = map[string]interface{}
+
+func link(href string) object {
+	return object{"href": href}
+}
+
+// resource adds the uid and the self link of a record of the collection to its HAL representation.
+func (c *call) resource(collection string, uid string, fields object) object {
+	fields["uid"] = uid
+	links, ok := fields["_links"].(object)
+	if !ok {
+		links = object{}
+		fields["_links"] = links
+	}
+	links["self"] = link(c.href(collection, uid))
+	return fields
+}
+
+// href returns the URL of the record of the collection.
+func (c *call) href(collection string, uid string) string {
+	return c.base + "/" + collection + "/" + uid
+}
+
+// nullable returns nil for an empty optional value, which the platform sends as null.
+func nullable(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// page returns the page of the collection requested with the page and size parameters, as a HAL collection
+// with the items embedded under the relation and a next link when there are more pages.
+func (s *Server) page(c *call, relation string, items []interface{}) object {
+	query := c.URL.Query()
+	size, err := strconv.Atoi(query.Get("size"))
+	if err != nil || size <= 0 {
+		size = s.pageSize
+	}
+	number, err := strconv.Atoi(query.Get("page"))
+	if err != nil || number < 0 {
+		number = 0
+	}
+
+	total := len(items)
+	start := min(number*size, total)
+	end := min(start+size, total)
+	embedded := append([]interface{}{}, items[start:end]...)
+
+	self := url.URL{Path: c.URL.Path, RawQuery: query.Encode()}
+	links := object{"self": link(c.origin + self.String())}
+	if end < total {
+		query.Set("page", strconv.Itoa(number+1))
+		next := url.URL{Path: c.URL.Path, RawQuery: query.Encode()}
+		links["next"] = link(c.origin + next.String())
+	}
+	return object{
+		"_embedded": object{relation: embedded},
+		"_links":    links,
+		"page": object{
+			"size":          size,
+			"totalElements": total,
+			"totalPages":    (total + size - 1) / size,
+			"number":        number,
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package fakeapi
+
+import "net/http"
+
+func (s *Server) routeInstances() {
+	s.route("GET /instances", func(c *call) (interface{}, error) {
+		return s.page(c, "instances", instanceViews(c, s.state.Instances)), nil
+	})
+	s.route("GET /instances/{id}", func(c *call) (interface{}, error) {
+		instance, ok := find(s.state.Instances, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("instance", c.PathValue("id"))
+		}
+		return instanceView(c, instance), nil
+	})
+	s.route("GET /instances/search/findByName", func(c *call) (interface{}, error) {
+		return s.findInstance(c, func(i *Instance) bool { return i.Name == c.URL.Query().Get("name") })
+	})
+	s.route("GET /instances/search/findByShortName", func(c *call) (interface{}, error) {
+		return s.findInstance(c, func(i *Instance) bool { return i.ShortName == c.URL.Query().Get("shortName") })
+	})
+	s.route("GET /instances/search/findByAttributes", func(c *call) (interface{}, error) {
+		query := c.URL.Query()
+		instances := filter(s.state.Instances, func(i *Instance) bool {
+			return (!query.Has("name") || i.Name == query.Get("name")) &&
+				(!query.Has("shortName") || i.ShortName == query.Get("shortName"))
+		})
+		return s.page(c, "instances", instanceViews(c, instances)), nil
+	})
+}
+
+// findInstance returns the single instance matching, like the findByName and findByShortName searches.
+func (s *Server) findInstance(c *call, match func(*Instance) bool) (interface{}, error) {
+	instances := filter(s.state.Instances, match)
+	if len(instances) == 0 {
+		return nil, &httpError{status: http.StatusNotFound, message: "No instance found"}
+	}
+	return instanceView(c, instances[0]), nil
+}
+
+func instanceViews(c *call, instances []*Instance) []interface{} {
+	views := []interface{}{}
+	for _, instance := range instances {
+		views = append(views, instanceView(c, instance))
+	}
+	return views
+}
+
+func instanceView(c *call, instance *Instance) object {
+	return c.resource("instances", instance.Uid, object{
+		"name":        instance.Name,
+		"shortName":   instance.ShortName,
+		"description": nullable(instance.Description),
+	})
+}
@@ -0,0 +1,257 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+func (s *Server) routeSchemas() {
+	s.route("POST /schemas/check-parse", func(c *call) (interface{}, error) {
+		var request struct {
+			Schema string  `json:"schema"`
+			Type   *string `json:"type"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		fullName, err := schemaFullName(request.Schema, schemaType(request.Type))
+		if err != nil {
+			return nil, badRequest(err.Error(), fieldError{Field: "schema", Message: err.Error()})
+		}
+		versions := []string{}
+		if schema := s.schemaByName(fullName); schema != nil {
+			for _, version := range s.schemaVersions(schema.Uid) {
+				versions = append(versions, version.Version)
+			}
+		}
+		return object{"schema": request.Schema, "version": versions, "fullName": fullName}, nil
+	})
+	s.route("POST /schemas/upload", func(c *call) (interface{}, error) {
+		var request struct {
+			Schema      string  `json:"schema"`
+			Version     string  `json:"version"`
+			Description string  `json:"description"`
+			Owners      *string `json:"owners"`
+			Type        *string `json:"type"`
+		}
+		if err := c.decode(&request); err != nil {
+			return nil, err
+		}
+		if err := required(map[string]string{"schema": request.Schema, "version": request.Version}); err != nil {
+			return nil, err
+		}
+		fullName, err := schemaFullName(request.Schema, schemaType(request.Type))
+		if err != nil {
+			return nil, badRequest(err.Error(), fieldError{Field: "schema", Message: err.Error()})
+		}
+		schema := s.schemaByName(fullName)
+		if schema == nil {
+			schema = &Schema{Record: Record{Uid: newUID()}, Name: fullName, Description: request.Description, Type: schemaType(request.Type)}
+			if request.Owners != nil {
+				schema.Owners = ref(*request.Owners)
+				if err := s.requireGroup("owners", schema.Owners); err != nil {
+					return nil, err
+				}
+			}
+			s.state.Schemas = append(s.state.Schemas, schema)
+		}
+		for _, version := range s.schemaVersions(schema.Uid) {
+			if version.Version == request.Version {
+				return nil, conflict(fmt.Sprintf("Version %s of schema %s already exists", request.Version, fullName),
+					fieldError{Field: "version", Message: "must be unique"})
+			}
+		}
+		version := &SchemaVersion{Record: Record{Uid: newUID()}, Schema: schema.Uid, Version: request.Version, SchemaBody: request.Schema}
+		s.state.SchemaVersions = append(s.state.SchemaVersions, version)
+
+		var owners interface{}
+		if group, ok := find(s.state.Groups, schema.Owners); ok {
+			owners = object{"id": group.Uid, "name": group.Name}
+		}
+		return created{object{
+			"schemaVersionUid": version.Uid,
+			"schemaUid":        schema.Uid,
+			"version":          version.Version,
+			"fullName":         schema.Name,
+			"owners":           owners,
+		}}, nil
+	})
+	s.route("GET /schemas/{id}", func(c *call) (interface{}, error) {
+		schema, ok := find(s.state.Schemas, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("schema", c.PathValue("id"))
+		}
+		return s.schemaView(c, schema), nil
+	})
+	s.route("GET /schemas/search/findByName", func(c *call) (interface{}, error) {
+		views := []interface{}{}
+		if schema := s.schemaByName(c.URL.Query().Get("name")); schema != nil {
+			views = append(views, s.schemaView(c, schema))
+		}
+		return s.page(c, "schemas", views), nil
+	})
+	s.route("GET /schema_versions/{id}", func(c *call) (interface{}, error) {
+		version, ok := find(s.state.SchemaVersions, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("schema version", c.PathValue("id"))
+		}
+		return s.schemaVersionView(c, version), nil
+	})
+	s.route("DELETE /schema_versions/{id}", func(c *call) (interface{}, error) {
+		uid := c.PathValue("id")
+		version, ok := find(s.state.SchemaVersions, uid)
+		if !ok {
+			return nil, notFound("schema version", uid)
+		}
+		for _, config := range s.state.TopicConfigs {
+			if config.KeySchemaVersion == uid || config.ValueSchemaVersion == uid {
+				return nil, conflict(fmt.Sprintf("Schema version %s can not be deleted, it is used by topic config %s", uid, config.Uid))
+			}
+		}
+		s.state.SchemaVersions = remove(s.state.SchemaVersions, uid)
+		// A schema without versions is removed, unless a topic still refers to it.
+		if len(s.schemaVersions(version.Schema)) == 0 && !slices.ContainsFunc(s.state.Topics, func(t *Topic) bool {
+			return t.KeySchema == version.Schema || t.ValueSchema == version.Schema
+		}) {
+			s.state.Schemas = remove(s.state.Schemas, version.Schema)
+		}
+		return nil, nil
+	})
+	s.route("GET /schema_versions/search/findAllBySchema", func(c *call) (interface{}, error) {
+		schema, ok := find(s.state.Schemas, ref(c.URL.Query().Get("schema")))
+		views := []interface{}{}
+		if ok {
+			for _, version := range s.schemaVersions(schema.Uid) {
+				var owners interface{}
+				if group, ok := find(s.state.Groups, schema.Owners); ok {
+					owners = object{"uid": group.Uid, "name": group.Name}
+				}
+				views = append(views, c.resource("schema_versions", version.Uid, object{
+					"version":    version.Version,
+					"schemaBody": version.SchemaBody,
+					"_embedded": object{
+						"schema": c.resource("schemas", schema.Uid, object{
+							"name":        schema.Name,
+							"description": nullable(schema.Description),
+							"owners":      owners,
+						}),
+					},
+				}))
+			}
+		}
+		return s.page(c, "schema_versions", views), nil
+	})
+}
+
+func (s *Server) schemaByName(name string) *Schema {
+	for _, schema := range s.state.Schemas {
+		if schema.Name == name {
+			return schema
+		}
+	}
+	return nil
+}
+
+func (s *Server) schemaVersions(schema string) []*SchemaVersion {
+	return filter(s.state.SchemaVersions, func(v *SchemaVersion) bool { return v.Schema == schema })
+}
+
+// schemaRef returns the embedded reference to the schema, or nil.
+func (s *Server) schemaRef(c *call, uid string) interface{} {
+	schema, ok := find(s.state.Schemas, uid)
+	if !ok {
+		return nil
+	}
+	return c.resource("schemas", schema.Uid, object{"name": schema.Name})
+}
+
+func (s *Server) schemaView(c *call, schema *Schema) object {
+	return c.resource("schemas", schema.Uid, object{
+		"name":        schema.Name,
+		"description": nullable(schema.Description),
+		"type":        schema.Type,
+		"owners":      s.groupRef(c, schema.Owners),
+	})
+}
+
+// schemaVersionView returns a schema version with its schema, which is not embedded in this response.
+func (s *Server) schemaVersionView(c *call, version *SchemaVersion) object {
+	schema := object{}
+	if parent, ok := find(s.state.Schemas, version.Schema); ok {
+		var owners interface{}
+		if group, ok := find(s.state.Groups, parent.Owners); ok {
+			owners = object{"id": group.Uid, "name": group.Name}
+		}
+		schema = object{
+			"id":          parent.Uid,
+			"name":        parent.Name,
+			"description": nullable(parent.Description),
+			"type":        parent.Type,
+			"owners":      owners,
+		}
+	}
+	return c.resource("schema_versions", version.Uid, object{
+		"id":         version.Uid,
+		"version":    version.Version,
+		"schemaBody": version.SchemaBody,
+		"schema":     schema,
+	})
+}
+
+func schemaType(t *string) string {
+	if t == nil || *t == "" {
+		return "AVRO"
+	}
+	return *t
+}
+
+var (
+	protobufPackage = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)\s*;`)
+	protobufMessage = regexp.MustCompile(`(?m)^\s*message\s+(\w+)`)
+)
+
+// schemaFullName returns the name the platform registers the schema under: the full name of an Avro record,
+// the title of a JSON schema, or the first message of a Protobuf schema with its package.
+func schemaFullName(body string, schemaType string) (string, error) {
+	switch schemaType {
+	case "PROTOBUF":
+		message := protobufMessage.FindStringSubmatch(body)
+		if message == nil {
+			return "", errors.New("Invalid Protobuf schema: no message found")
+		}
+		if pkg := protobufPackage.FindStringSubmatch(body); pkg != nil {
+			return pkg[1] + "." + message[1], nil
+		}
+		return message[1], nil
+	case "JSON_SCHEMA":
+		var schema struct {
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal([]byte(body), &schema); err != nil {
+			return "", fmt.Errorf("Invalid JSON schema: %s", err)
+		}
+		if schema.Title == "" {
+			return "", errors.New("Invalid JSON schema: title is required")
+		}
+		return schema.Title, nil
+	default:
+		var schema struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		}
+		if err := json.Unmarshal([]byte(body), &schema); err != nil {
+			return "", fmt.Errorf("Invalid Avro schema: %s", err)
+		}
+		if schema.Name == "" {
+			return "", errors.New("Invalid Avro schema: name is required")
+		}
+		if schema.Namespace == "" || strings.Contains(schema.Name, ".") {
+			return schema.Name, nil
+		}
+		return schema.Namespace + "." + schema.Name, nil
+	}
+}
@@ -0,0 +1,337 @@
+// Package fakeapi is an in-memory stand-in for the Axual self-service API and its token endpoint. It serves the
+// HAL endpoints the webclient uses, with the same URLs, payloads and status codes, so the provider can be tested
+// without a platform. Unlike the platform, every change is applied right away.
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// APIPath is the path of the API on the server, e.g. http://127.0.0.1:8080/api.
+const APIPath = "/api"
+
+// DefaultVersion is the Platform Manager version reported by the actuator info endpoint.
+const DefaultVersion = "15.0.0"
+
+// defaultPageSize is the page size of collections without a size parameter, as in Spring Data REST.
+const defaultPageSize = 20
+
+// APIURL returns the URL of the API served at serverURL, e.g. the URL of an httptest.Server.
+func APIURL(serverURL string) string {
+	return strings.TrimSuffix(serverURL, "/") + APIPath
+}
+
+// TokenURL returns the Keycloak token endpoint of the realm served at serverURL.
+func TokenURL(serverURL string, realm string) string {
+	return fmt.Sprintf("%s/auth/realms/%s/protocol/openid-connect/token", strings.TrimSuffix(serverURL, "/"), realm)
+}
+
+// Server serves the fake API. It is an http.Handler, usually started with httptest.NewServer.
+type Server struct {
+	mu       sync.Mutex
+	state    *State
+	tokens   map[string]bool
+	mux      *http.ServeMux
+	username string
+	password string
+	version  string
+	pageSize int
+	// autoApproval approves every access grant right away, not only those in environments with AutoApproved.
+	autoApproval bool
+	onChange     func(state *State)
+}
+
+// Option configures optional Server settings in New.
+type Option func(*Server)
+
+// WithCredentials only accepts the username and password in the password grant of the token endpoint.
+// Without it, any credentials are accepted.
+func WithCredentials(username string, password string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithVersion replaces the DefaultVersion reported by the actuator info endpoint.
+// An empty version makes the endpoint respond with 404 Not Found, like platforms that do not expose it.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithPageSize replaces the default page size of 20 of the collections.
+func WithPageSize(size int) Option {
+	return func(s *Server) {
+		s.pageSize = size
+	}
+}
+
+// WithAutoApproval approves every access grant when it is requested, as if every environment had AutoApproved set.
+func WithAutoApproval() Option {
+	return func(s *Server) {
+		s.autoApproval = true
+	}
+}
+
+// WithOnChange calls onChange with the state after every request that changed it, e.g. to persist it.
+// The state must not be changed or kept; onChange is called while requests are blocked.
+func WithOnChange(onChange func(state *State)) Option {
+	return func(s *Server) {
+		s.onChange = onChange
+	}
+}
+
+// New returns a Server serving the seed, which it takes ownership of. A nil seed starts empty;
+// seeded records without a uid get one.
+func New(seed *State, options ...Option) *Server {
+	if seed == nil {
+		seed = &State{}
+	}
+	seed.assignUIDs()
+	s := &Server{
+		state:    seed,
+		tokens:   map[string]bool{},
+		mux:      http.NewServeMux(),
+		version:  DefaultVersion,
+		pageSize: defaultPageSize,
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	s.mux.HandleFunc("POST /auth/realms/{realm}/protocol/openid-connect/token", s.token)
+	s.mux.HandleFunc("GET "+APIPath+"/actuator/info", s.actuatorInfo)
+	s.routeInstances()
+	s.routeUsers()
+	s.routeGroups()
+	s.routeEnvironments()
+	s.routeTopics()
+	s.routeTopicConfigs()
+	s.routeApplications()
+	s.routePrincipals()
+	s.routeCredentials()
+	s.routeGrants()
+	s.routeDeployments()
+	s.routeSchemas()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// token implements the Keycloak token endpoint for every grant type, issuing opaque bearer tokens.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+	if r.Form.Get("grant_type") == "password" && s.username != "" &&
+		(r.Form.Get("username") != s.username || r.Form.Get("password") != s.password) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_grant", "error_description": "Invalid user credentials"})
+		return
+	}
+
+	s.mu.Lock()
+	token := newUID()
+	s.tokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  token,
+		"token_type":    "Bearer",
+		"expires_in":    300,
+		"refresh_token": newUID(),
+		"scope":         "openid profile email",
+	})
+}
+
+// actuatorInfo reports the build info of the Platform Manager, used to detect the platform version.
+func (s *Server) actuatorInfo(w http.ResponseWriter, r *http.Request) {
+	if s.version == "" {
+		writeError(w, notFound("actuator endpoint", "info"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"build": map[string]string{
+			"name":    "platform-manager",
+			"version": s.version,
+			"time":    "2025-01-01T00:00:00Z",
+		},
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[token]
+}
+
+// call is a single API request handled under the lock of the Server.
+type call struct {
+	*http.Request
+	body []byte
+	// origin is the scheme and host of the server as the client sees it, used for the links in responses.
+	origin string
+	// base is the URL of the API as the client sees it.
+	base string
+}
+
+func (c *call) decode(v interface{}) error {
+	if err := json.Unmarshal(c.body, v); err != nil {
+		return badRequest(fmt.Sprintf("Malformed request body: %s", err))
+	}
+	return nil
+}
+
+// created is returned by handlers for a 201 Created response.
+type created struct {
+	body interface{}
+}
+
+// route adds the handler of an API endpoint; the pattern is relative to APIPath. A handler returns the body of
+// a 200 OK response, created for 201 Created, nil for 204 No Content, or an *httpError.
+func (s *Server) route(pattern string, handler func(c *call) (interface{}, error)) {
+	method, path, _ := strings.Cut(pattern, " ")
+	s.mux.HandleFunc(method+" "+APIPath+path, func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			writeError(w, &httpError{status: http.StatusUnauthorized, message: "Full authentication is required to access this resource"})
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, badRequest(err.Error()))
+			return
+		}
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+
+		s.mu.Lock()
+		origin := scheme + "://" + r.Host
+		result, err := handler(&call{Request: r, body: body, origin: origin, base: origin + APIPath})
+		if err == nil && r.Method != http.MethodGet && s.onChange != nil {
+			s.onChange(s.state)
+		}
+		s.mu.Unlock()
+
+		var httpErr *httpError
+		switch {
+		case errors.As(err, &httpErr):
+			writeError(w, httpErr)
+		case err != nil:
+			writeError(w, &httpError{status: http.StatusInternalServerError, message: err.Error()})
+		case result == nil:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			if c, ok := result.(created); ok {
+				writeJSON(w, http.StatusCreated, c.body)
+				return
+			}
+			writeJSON(w, http.StatusOK, result)
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// httpError is an error response in the format of Spring Boot, with the validation errors per field.
+type httpError struct {
+	status  int
+	message string
+	fields  []fieldError
+}
+
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("%d %s", e.status, e.message)
+}
+
+func writeError(w http.ResponseWriter, e *httpError) {
+	body := map[string]interface{}{
+		"status":  e.status,
+		"error":   http.StatusText(e.status),
+		"message": e.message,
+	}
+	if len(e.fields) > 0 {
+		body["errors"] = e.fields
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func notFound(kind string, uid string) *httpError {
+	return &httpError{status: http.StatusNotFound, message: fmt.Sprintf("No %s found with uid %s", kind, uid)}
+}
+
+func badRequest(message string, fields ...fieldError) *httpError {
+	return &httpError{status: http.StatusBadRequest, message: message, fields: fields}
+}
+
+func conflict(message string, fields ...fieldError) *httpError {
+	return &httpError{status: http.StatusConflict, message: message, fields: fields}
+}
+
+// required returns a validation error for the fields without a value.
+func required(fields map[string]string) error {
+	var missing []fieldError
+	for field, value := range fields {
+		if value == "" {
+			missing = append(missing, fieldError{Field: field, Message: "must not be blank"})
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Field < missing[j].Field })
+	return badRequest("Validation failed", missing...)
+}
+
+// ref returns the uid of a reference, which clients send as a uid or as the URL of the resource.
+func ref(reference string) string {
+	reference = strings.TrimSuffix(reference, "/")
+	if i := strings.LastIndex(reference, "/"); i >= 0 {
+		return reference[i+1:]
+	}
+	return reference
+}
+
+func refs(references []string) []string {
+	uids := make([]string, 0, len(references))
+	for _, reference := range references {
+		uids = append(uids, ref(reference))
+	}
+	return uids
+}
+
+// newUID returns a random uid in the format of the platform.
+func newUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
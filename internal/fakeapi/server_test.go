@@ -0,0 +1,366 @@
+package fakeapi_test
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"axual.com/terraform-provider-axual/internal/fakeapi"
+)
+
+// seed returns the records a platform has before any resource is created: an instance, a user and a group.
+func seed() *fakeapi.State {
+	return &fakeapi.State{
+		Instances: []*fakeapi.Instance{{Record: fakeapi.Record{Uid: "instance-uid"}, Name: "Dev Test Acceptance", ShortName: "dta"}},
+		Users:     []*fakeapi.User{{Record: fakeapi.Record{Uid: "user-uid"}, FirstName: "Kenny", EmailAddress: "kenny@example.com"}},
+		Groups:    []*fakeapi.Group{{Record: fakeapi.Record{Uid: "team-uid"}, Name: "Team Awesome", Members: []string{"user-uid"}}},
+	}
+}
+
+// newClient returns a webclient for a new fake API started with the options.
+func newClient(t *testing.T, options ...fakeapi.Option) *webclient.Client {
+	t.Helper()
+	server := httptest.NewServer(fakeapi.New(seed(), append([]fakeapi.Option{fakeapi.WithCredentials("kenny", "secret")}, options...)...))
+	t.Cleanup(server.Close)
+	auth := webclient.AuthStruct{Username: "kenny", Password: "secret", Url: fakeapi.TokenURL(server.URL, "axual"), ClientId: "self-service", AuthMode: "keycloak"}
+	client, err := webclient.NewClient(context.Background(), fakeapi.APIURL(server.URL), "axual", auth,
+		webclient.WithRetryPolicy(webclient.RetryPolicy{}),
+		webclient.WithPollingPolicy(webclient.PollingPolicy{Interval: time.Millisecond, Timeout: time.Second}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestTokenEndpoint(t *testing.T) {
+	server := httptest.NewServer(fakeapi.New(seed(), fakeapi.WithCredentials("kenny", "secret")))
+	defer server.Close()
+
+	testCases := []struct {
+		desc     string
+		password string
+		wantErr  bool
+	}{
+		{desc: "the configured credentials get a token", password: "secret"},
+		{desc: "other credentials are rejected", password: "wrong", wantErr: true},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			auth := webclient.AuthStruct{Username: "kenny", Password: c.password, Url: fakeapi.TokenURL(server.URL, "axual"), ClientId: "self-service", AuthMode: "keycloak"}
+			client, err := webclient.NewClient(context.Background(), fakeapi.APIURL(server.URL), "axual", auth)
+			if err == nil {
+				_, err = client.GetGroup(context.Background(), "team-uid")
+			}
+			if (err != nil) != c.wantErr {
+				t.Fatalf("expected error %v, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPlatformVersion(t *testing.T) {
+	info, err := newClient(t, fakeapi.WithVersion("14.2.0")).DetectPlatform(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "14.2.0" {
+		t.Errorf("expected version 14.2.0, got %+v", info)
+	}
+}
+
+func TestTopicLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+	groupURL := client.BaseURL() + "/groups/team-uid"
+
+	environment, err := client.CreateEnvironment(ctx, webclient.EnvironmentRequest{
+		Name: "development", ShortName: "dev", Color: "#19b9be", AuthorizationIssuer: "Stream owner", Visibility: "Public",
+		Instance: client.BaseURL() + "/instances/instance-uid", Owners: groupURL, Partitions: 1, RetentionTime: 86400000,
+		Properties: map[string]interface{}{"segment.ms": "60000"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if environment.Embedded.Instance.Uid != "instance-uid" || environment.Embedded.Owners.Name != "Team Awesome" {
+		t.Errorf("expected the instance and owners to be embedded, got %+v", environment.Embedded)
+	}
+	if _, err := client.CreateEnvironment(ctx, webclient.EnvironmentRequest{
+		Name: "development", ShortName: "dev2", Color: "#19b9be", AuthorizationIssuer: "Stream owner", Visibility: "Public",
+		Instance: client.BaseURL() + "/instances/instance-uid", Owners: groupURL,
+	}); !errors.Is(err, webclient.ConflictError) {
+		t.Errorf("expected a conflict for a duplicate name, got %v", err)
+	}
+
+	topic, err := client.CreateTopic(ctx, webclient.TopicRequest{
+		Name: "orders", KeyType: "String", ValueType: "String", Owners: groupURL, RetentionPolicy: "delete",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	topicConfig, err := client.CreateTopicConfig(ctx, webclient.TopicConfigRequest{
+		Stream: client.BaseURL() + "/streams/" + topic.Uid, Environment: client.BaseURL() + "/environments/" + environment.Uid,
+		Partitions: 3, RetentionTime: 3600000, Properties: map[string]interface{}{"segment.ms": "600012"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topicConfig.Partitions != 3 || topicConfig.Properties["segment.ms"] != "600012" {
+		t.Errorf("unexpected topic config %+v", topicConfig)
+	}
+
+	if err := client.DeleteTopic(ctx, topic.Uid); !errors.Is(err, webclient.ConflictError) {
+		t.Errorf("expected a conflict deleting a topic with configs, got %v", err)
+	}
+	if err := client.DeleteTopicConfig(ctx, topicConfig.Uid); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteTopic(ctx, topic.Uid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTopic(ctx, topic.Uid); !errors.Is(err, webclient.NotFoundError) {
+		t.Errorf("expected the topic to be gone, got %v", err)
+	}
+}
+
+func TestAccessGrantApproval(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		autoApproved bool
+		options      []fakeapi.Option
+		wantStatus   string
+	}{
+		{desc: "grants need approval", wantStatus: "Pending"},
+		{desc: "grants in auto approved environments are approved right away", autoApproved: true, wantStatus: "Approved"},
+		{desc: "grants are approved right away with auto approval", options: []fakeapi.Option{fakeapi.WithAutoApproval()}, wantStatus: "Approved"},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx := context.Background()
+			client := newClient(t, c.options...)
+			environment, application, topic := deploy(t, client)
+			if c.autoApproved {
+				// The environment resource does not manage the setting, so it is set in the request directly.
+				if err := client.RequestAndMap(ctx, "PATCH", client.BaseURL()+"/environments/"+environment, strings.NewReader(`{"autoApproved": true}`), nil, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			grant, err := client.CreateApplicationAccessGrant(ctx, webclient.ApplicationAccessGrantRequest{
+				ApplicationId: application, StreamId: topic, EnvironmentId: environment, AccessType: "Consumer",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if grant.Status != c.wantStatus {
+				t.Fatalf("expected status %s, got %s", c.wantStatus, grant.Status)
+			}
+			if grant.Status == "Pending" {
+				if err := client.ApproveGrant(ctx, grant.Uid); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			approved, err := client.GetApplicationAccessGrantsByAttributes(ctx, webclient.ApplicationAccessGrantAttributes{
+				ApplicationId: application, EnvironmentId: environment, Statuses: "APPROVED",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if grants := approved.Embedded.ApplicationAccessGrantResponses; len(grants) != 1 || grants[0].Uid != grant.Uid {
+				t.Errorf("expected the grant to be approved, got %+v", grants)
+			}
+			if err := client.RevokeOrDenyGrant(ctx, grant.Uid, "no longer needed"); err != nil {
+				t.Fatal(err)
+			}
+			revoked, err := client.GetApplicationAccessGrant(ctx, grant.Uid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if revoked.Status != "Revoked" || revoked.Comment != "no longer needed" {
+				t.Errorf("expected the grant to be revoked, got %+v", revoked)
+			}
+		})
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	var groups []string
+	client := newClient(t, fakeapi.WithOnChange(func(state *fakeapi.State) {
+		groups = groups[:0]
+		for _, group := range state.Groups {
+			groups = append(groups, group.Name)
+		}
+	}))
+	if _, err := client.GetGroup(context.Background(), "team-uid"); err != nil {
+		t.Fatal(err)
+	}
+	if groups != nil {
+		t.Errorf("expected no change for a read, got %v", groups)
+	}
+	if _, err := client.CreateGroup(context.Background(), webclient.GroupRequest{Name: "Team Integrations"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[1] != "Team Integrations" {
+		t.Errorf("expected the state with the new group, got %v", groups)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, fakeapi.WithPageSize(2))
+	environment, application, _ := deploy(t, client)
+	for _, name := range []string{"payments", "invoices", "refunds", "returns"} {
+		topic, err := client.CreateTopic(ctx, webclient.TopicRequest{
+			Name: name, KeyType: "String", ValueType: "String", Owners: client.BaseURL() + "/groups/team-uid", RetentionPolicy: "delete",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.CreateTopicConfig(ctx, webclient.TopicConfigRequest{
+			Stream: client.BaseURL() + "/streams/" + topic.Uid, Environment: client.BaseURL() + "/environments/" + environment,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.CreateApplicationAccessGrant(ctx, webclient.ApplicationAccessGrantRequest{
+			ApplicationId: application, StreamId: topic.Uid, EnvironmentId: environment, AccessType: "Producer",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	grants, err := client.GetApplicationAccessGrantsByAttributes(ctx, webclient.ApplicationAccessGrantAttributes{
+		ApplicationId: application, AccessType: "Producer",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(grants.Embedded.ApplicationAccessGrantResponses); n != 4 {
+		t.Errorf("expected the grants of all pages, got %d", n)
+	}
+}
+
+func TestPrincipalAndDeployment(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+	environment, application, _ := deploy(t, client)
+	applicationURL := client.BaseURL() + "/applications/" + application
+	environmentURL := client.BaseURL() + "/environments/" + environment
+
+	principalID, err := client.CreateApplicationPrincipal(ctx, [1]webclient.ApplicationPrincipalRequest{{
+		Principal: certificate(t, "CN=orders-connector,O=Axual"), Application: applicationURL, Environment: environmentURL,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal, err := client.ReadApplicationPrincipal(ctx, string(principalID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.Principal != "CN=orders-connector,O=Axual" || principal.Type != "SSL" || *principal.Active {
+		t.Errorf("expected an inactive SSL principal with the subject of the certificate, got %+v", principal)
+	}
+
+	if _, err := client.CreateApplicationDeployment(ctx, webclient.ApplicationDeploymentCreateRequest{
+		Application: applicationURL, Environment: environmentURL, Configs: map[string]string{"connector.class": "FileStreamSink"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	deployments, err := client.FindApplicationDeploymentByApplicationAndEnvironment(ctx, applicationURL, environmentURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments.Embedded.ApplicationDeploymentResponses) != 1 {
+		t.Fatalf("expected one deployment, got %+v", deployments)
+	}
+	deployment := deployments.Embedded.ApplicationDeploymentResponses[0].Uid
+
+	if err := client.OperateApplicationDeployment(ctx, deployment, "START", webclient.ApplicationDeploymentOperationRequest{Action: "START"}); err == nil {
+		t.Error("expected a Connector without an active principal not to start")
+	}
+	if err := client.ActivateApplicationPrincipal(ctx, string(principalID)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.OperateApplicationDeployment(ctx, deployment, "START", webclient.ApplicationDeploymentOperationRequest{Action: "START"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.OperateApplicationDeployment(ctx, deployment, "START", webclient.ApplicationDeploymentOperationRequest{Action: "START"}); !errors.Is(err, webclient.InvalidDeploymentStateError) {
+		t.Errorf("expected starting a running deployment to be an invalid action, got %v", err)
+	}
+	status, err := client.GetApplicationDeploymentStatus(ctx, deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.ConnectorState.State != "Running" {
+		t.Errorf("expected the connector to run, got %+v", status)
+	}
+	if err := client.OperateApplicationDeployment(ctx, deployment, "STOP", webclient.ApplicationDeploymentOperationRequest{Action: "STOP"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteApplicationDeployment(ctx, deployment); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deploy creates an environment, a Connector application and a topic configured in the environment,
+// and returns their uids.
+func deploy(t *testing.T, client *webclient.Client) (environment string, application string, topic string) {
+	t.Helper()
+	ctx := context.Background()
+	groupURL := client.BaseURL() + "/groups/team-uid"
+	env, err := client.CreateEnvironment(ctx, webclient.EnvironmentRequest{
+		Name: "staging", ShortName: "staging", Color: "#19b9be", AuthorizationIssuer: "Auto", Visibility: "Public",
+		Instance: client.BaseURL() + "/instances/instance-uid", Owners: groupURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := client.CreateApplication(ctx, webclient.ApplicationRequest{
+		ApplicationType: "Connector", ApplicationId: "io.axual.orders", Name: "Orders", ShortName: "orders",
+		Owners: groupURL, Type: "SINK", ApplicationClass: "FileStreamSink", Visibility: "Public",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpc, err := client.CreateTopic(ctx, webclient.TopicRequest{
+		Name: "orders", KeyType: "String", ValueType: "String", Owners: groupURL, RetentionPolicy: "delete",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateTopicConfig(ctx, webclient.TopicConfigRequest{
+		Stream: client.BaseURL() + "/streams/" + tpc.Uid, Environment: client.BaseURL() + "/environments/" + env.Uid,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return env.Uid, app.Uid, tpc.Uid
+}
+
+// certificate returns a self-signed PEM certificate with the subject.
+func certificate(t *testing.T, subject string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := pkix.Name{CommonName: "orders-connector", Organization: []string{"Axual"}}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: name, NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name.String() != subject {
+		t.Fatalf("the subject %q does not match %q", name.String(), subject)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -0,0 +1,252 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// State holds every record of the fake API. It doubles as the seed of a Server. References between records
+// hold the uid of the referenced record; the JSON names of the fields are the names in the API requests.
+type State struct {
+	Instances      []*Instance      `json:"instances,omitempty"`
+	Users          []*User          `json:"users,omitempty"`
+	Groups         []*Group         `json:"groups,omitempty"`
+	Environments   []*Environment   `json:"environments,omitempty"`
+	Topics         []*Topic         `json:"streams,omitempty"`
+	TopicConfigs   []*TopicConfig   `json:"stream_configs,omitempty"`
+	Applications   []*Application   `json:"applications,omitempty"`
+	Principals     []*Principal     `json:"application_principals,omitempty"`
+	Credentials    []*Credential    `json:"application_credentials,omitempty"`
+	Grants         []*Grant         `json:"application_access_grants,omitempty"`
+	Deployments    []*Deployment    `json:"application_deployments,omitempty"`
+	Schemas        []*Schema        `json:"schemas,omitempty"`
+	SchemaVersions []*SchemaVersion `json:"schema_versions,omitempty"`
+}
+
+// Record is the part every record has in common.
+type Record struct {
+	Uid string `json:"uid"`
+}
+
+func (r *Record) record() *Record {
+	return r
+}
+
+type record interface {
+	record() *Record
+}
+
+type Instance struct {
+	Record
+	Name        string `json:"name"`
+	ShortName   string `json:"shortName"`
+	Description string `json:"description"`
+}
+
+type User struct {
+	Record
+	FirstName    string `json:"firstName"`
+	LastName     string `json:"lastName"`
+	MiddleName   string `json:"middleName"`
+	EmailAddress string `json:"emailAddress"`
+	PhoneNumber  string `json:"phoneNumber"`
+	Roles        []Role `json:"roles"`
+}
+
+type Role struct {
+	Name string `json:"name"`
+}
+
+type Group struct {
+	Record
+	Name         string   `json:"name"`
+	EmailAddress string   `json:"emailAddress"`
+	PhoneNumber  string   `json:"phoneNumber"`
+	Members      []string `json:"members"`
+	Managers     []string `json:"managers"`
+}
+
+type Environment struct {
+	Record
+	Name                string                 `json:"name"`
+	ShortName           string                 `json:"shortName"`
+	Description         string                 `json:"description"`
+	Color               string                 `json:"color"`
+	RetentionTime       int                    `json:"retentionTime"`
+	Partitions          int                    `json:"partitions"`
+	AuthorizationIssuer string                 `json:"authorizationIssuer"`
+	Visibility          string                 `json:"visibility"`
+	Instance            string                 `json:"instance"`
+	Owners              string                 `json:"owners"`
+	Viewers             []string               `json:"viewers"`
+	Properties          map[string]interface{} `json:"properties"`
+	Settings            map[string]interface{} `json:"settings"`
+	// AutoApproved approves the access grants to topics in the environment right away.
+	AutoApproved bool `json:"autoApproved"`
+}
+
+type Topic struct {
+	Record
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	KeyType         string                 `json:"keyType"`
+	KeySchema       string                 `json:"keySchema"`
+	ValueType       string                 `json:"valueType"`
+	ValueSchema     string                 `json:"valueSchema"`
+	Owners          string                 `json:"owners"`
+	Viewers         []string               `json:"viewers"`
+	RetentionPolicy string                 `json:"retentionPolicy"`
+	Properties      map[string]interface{} `json:"properties"`
+}
+
+type TopicConfig struct {
+	Record
+	Stream             string                 `json:"stream"`
+	Environment        string                 `json:"environment"`
+	Partitions         int                    `json:"partitions"`
+	RetentionTime      int                    `json:"retentionTime"`
+	Properties         map[string]interface{} `json:"properties"`
+	KeySchemaVersion   string                 `json:"keySchemaVersion"`
+	ValueSchemaVersion string                 `json:"valueSchemaVersion"`
+	// BrowseUsers and BrowseGroups hold the users and groups with browse permission.
+	BrowseUsers  []string `json:"browseUsers,omitempty"`
+	BrowseGroups []string `json:"browseGroups,omitempty"`
+}
+
+type Application struct {
+	Record
+	ApplicationType  string   `json:"applicationType"`
+	ApplicationId    string   `json:"applicationId"`
+	Name             string   `json:"name"`
+	ShortName        string   `json:"shortName"`
+	Owners           string   `json:"owners"`
+	Viewers          []string `json:"viewers"`
+	Type             string   `json:"type"`
+	ApplicationClass string   `json:"applicationClass"`
+	Visibility       string   `json:"visibility"`
+	Description      string   `json:"description"`
+}
+
+type Principal struct {
+	Record
+	Application string `json:"application"`
+	Environment string `json:"environment"`
+	// Principal is the subject of the certificate, or the principal itself for custom principals.
+	Principal      string `json:"principal"`
+	ApplicationPem string `json:"applicationPem,omitempty"`
+	Type           string `json:"type"`
+	Active         bool   `json:"active"`
+}
+
+type Credential struct {
+	Record
+	Application string `json:"application"`
+	Environment string `json:"environment"`
+	Target      string `json:"target"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+}
+
+type Grant struct {
+	Record
+	Application string `json:"application"`
+	Stream      string `json:"stream"`
+	Environment string `json:"environment"`
+	AccessType  string `json:"accessType"`
+	Status      string `json:"status"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+type Deployment struct {
+	Record
+	Application string            `json:"application"`
+	Environment string            `json:"environment"`
+	Configs     map[string]string `json:"configs"`
+	State       string            `json:"state"`
+}
+
+type Schema struct {
+	Record
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Owners      string `json:"owners,omitempty"`
+}
+
+type SchemaVersion struct {
+	Record
+	Schema     string `json:"schema"`
+	Version    string `json:"version"`
+	SchemaBody string `json:"schemaBody"`
+}
+
+func (s *State) assignUIDs() {
+	for _, records := range []interface{}{s.Instances, s.Users, s.Groups, s.Environments, s.Topics, s.TopicConfigs,
+		s.Applications, s.Principals, s.Credentials, s.Grants, s.Deployments, s.Schemas, s.SchemaVersions} {
+		v := reflect.ValueOf(records)
+		for i := 0; i < v.Len(); i++ {
+			if r := v.Index(i).Interface().(record).record(); r.Uid == "" {
+				r.Uid = newUID()
+			}
+		}
+	}
+}
+
+// find returns the record with the uid.
+func find[T record](records []T, uid string) (T, bool) {
+	for _, r := range records {
+		if r.record().Uid == uid {
+			return r, true
+		}
+	}
+	var none T
+	return none, false
+}
+
+// filter returns the records matching keep.
+func filter[T record](records []T, keep func(T) bool) []T {
+	var matching []T
+	for _, r := range records {
+		if keep(r) {
+			matching = append(matching, r)
+		}
+	}
+	return matching
+}
+
+// remove returns the records without the one with the uid.
+func remove[T record](records []T, uid string) []T {
+	return filter(records, func(r T) bool { return r.record().Uid != uid })
+}
+
+// apply decodes a request body onto a record. A key in the body replaces the field with the same JSON name,
+// except for maps, which are merged, with null values removing keys, as the platform does for properties.
+func apply(body []byte, r record) error {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return badRequest("Malformed request body: " + err.Error())
+	}
+	v := reflect.ValueOf(r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if _, ok := keys[name]; ok && name != "" && v.Field(i).Kind() != reflect.Map {
+			v.Field(i).SetZero()
+		}
+	}
+	if err := json.Unmarshal(body, r); err != nil {
+		return badRequest("Malformed request body: " + err.Error())
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Map || field.Type().Elem().Kind() != reflect.Interface {
+			continue
+		}
+		for _, key := range field.MapKeys() {
+			if field.MapIndex(key).IsNil() {
+				field.SetMapIndex(key, reflect.Value{})
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package fakeapi
+
+import (
+	"fmt"
+	"slices"
+)
+
+func (s *Server) routeTopicConfigs() {
+	s.route("GET /stream_configs", func(c *call) (interface{}, error) {
+		views := []interface{}{}
+		for _, config := range s.state.TopicConfigs {
+			views = append(views, s.topicConfigView(c, config))
+		}
+		return s.page(c, "stream_configs", views), nil
+	})
+	s.route("POST /stream_configs", func(c *call) (interface{}, error) {
+		config := &TopicConfig{Record: Record{Uid: newUID()}}
+		if err := s.applyTopicConfig(c, config); err != nil {
+			return nil, err
+		}
+		s.state.TopicConfigs = append(s.state.TopicConfigs, config)
+		return created{s.topicConfigView(c, config)}, nil
+	})
+	s.route("GET /stream_configs/{id}", func(c *call) (interface{}, error) {
+		config, ok := find(s.state.TopicConfigs, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("stream config", c.PathValue("id"))
+		}
+		return s.topicConfigView(c, config), nil
+	})
+	s.route("PATCH /stream_configs/{id}", func(c *call) (interface{}, error) {
+		config, ok := find(s.state.TopicConfigs, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("stream config", c.PathValue("id"))
+		}
+		updated := *config
+		updated.Properties = copyMap(config.Properties)
+		if err := s.applyTopicConfig(c, &updated); err != nil {
+			return nil, err
+		}
+		*config = updated
+		return s.topicConfigView(c, config), nil
+	})
+	s.route("DELETE /stream_configs/{id}", func(c *call) (interface{}, error) {
+		if _, ok := find(s.state.TopicConfigs, c.PathValue("id")); !ok {
+			return nil, notFound("stream config", c.PathValue("id"))
+		}
+		s.state.TopicConfigs = remove(s.state.TopicConfigs, c.PathValue("id"))
+		return nil, nil
+	})
+	s.route("GET /stream_configs/{id}/keySchemaVersion", func(c *call) (interface{}, error) {
+		return s.topicConfigSchemaVersion(c, func(config *TopicConfig) string { return config.KeySchemaVersion })
+	})
+	s.route("GET /stream_configs/{id}/valueSchemaVersion", func(c *call) (interface{}, error) {
+		return s.topicConfigSchemaVersion(c, func(config *TopicConfig) string { return config.ValueSchemaVersion })
+	})
+	s.route("GET /stream_configs/{id}/permissions", func(c *call) (interface{}, error) {
+		config, ok := find(s.state.TopicConfigs, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("stream config", c.PathValue("id"))
+		}
+		permissions := []interface{}{}
+		for _, user := range config.BrowseUsers {
+			permissions = append(permissions, object{"uid": user, "type": "USER"})
+		}
+		for _, group := range config.BrowseGroups {
+			permissions = append(permissions, object{"uid": group, "type": "GROUP"})
+		}
+		return permissions, nil
+	})
+	s.route("POST /stream_configs/{id}/permissions", func(c *call) (interface{}, error) {
+		return s.changePermissions(c, func(current []string, changed []string) []string {
+			for _, uid := range changed {
+				if !slices.Contains(current, uid) {
+					current = append(current, uid)
+				}
+			}
+			return current
+		})
+	})
+	s.route("DELETE /stream_configs/{id}/permissions", func(c *call) (interface{}, error) {
+		return s.changePermissions(c, func(current []string, changed []string) []string {
+			return slices.DeleteFunc(current, func(uid string) bool { return slices.Contains(changed, uid) })
+		})
+	})
+}
+
+// applyTopicConfig applies the request to the topic config and validates the result.
+func (s *Server) applyTopicConfig(c *call, config *TopicConfig) error {
+	if err := apply(c.body, config); err != nil {
+		return err
+	}
+	config.Stream = ref(config.Stream)
+	config.Environment = ref(config.Environment)
+	config.KeySchemaVersion = ref(config.KeySchemaVersion)
+	config.ValueSchemaVersion = ref(config.ValueSchemaVersion)
+	if err := required(map[string]string{"stream": config.Stream, "environment": config.Environment}); err != nil {
+		return err
+	}
+	if _, ok := find(s.state.Topics, config.Stream); !ok {
+		return badRequest("Validation failed", fieldError{Field: "stream", Message: fmt.Sprintf("stream %s does not exist", config.Stream)})
+	}
+	environment, ok := find(s.state.Environments, config.Environment)
+	if !ok {
+		return badRequest("Validation failed", fieldError{Field: "environment", Message: fmt.Sprintf("environment %s does not exist", config.Environment)})
+	}
+	for _, other := range s.state.TopicConfigs {
+		if other.Uid != config.Uid && other.Stream == config.Stream && other.Environment == config.Environment {
+			return conflict(fmt.Sprintf("Stream %s is already configured in environment %s", config.Stream, environment.ShortName))
+		}
+	}
+	for field, version := range map[string]string{"keySchemaVersion": config.KeySchemaVersion, "valueSchemaVersion": config.ValueSchemaVersion} {
+		if _, ok := find(s.state.SchemaVersions, version); version != "" && !ok {
+			return badRequest("Validation failed", fieldError{Field: field, Message: fmt.Sprintf("schema version %s does not exist", version)})
+		}
+	}
+	if config.Partitions == 0 {
+		config.Partitions = environment.Partitions
+	}
+	if config.RetentionTime == 0 {
+		config.RetentionTime = environment.RetentionTime
+	}
+	return nil
+}
+
+func (s *Server) topicConfigSchemaVersion(c *call, version func(*TopicConfig) string) (interface{}, error) {
+	config, ok := find(s.state.TopicConfigs, c.PathValue("id"))
+	if !ok {
+		return nil, notFound("stream config", c.PathValue("id"))
+	}
+	schemaVersion, ok := find(s.state.SchemaVersions, version(config))
+	if !ok {
+		return nil, notFound("schema version of stream config", config.Uid)
+	}
+	return s.schemaVersionView(c, schemaVersion), nil
+}
+
+// changePermissions changes the browse permissions of the users and groups in the request.
+func (s *Server) changePermissions(c *call, change func(current []string, changed []string) []string) (interface{}, error) {
+	config, ok := find(s.state.TopicConfigs, c.PathValue("id"))
+	if !ok {
+		return nil, notFound("stream config", c.PathValue("id"))
+	}
+	var request struct {
+		Type   string   `json:"type"`
+		Users  []string `json:"users"`
+		Groups []string `json:"groups"`
+	}
+	if err := c.decode(&request); err != nil {
+		return nil, err
+	}
+	if request.Type != "" && request.Type != "browse" {
+		return nil, badRequest("Validation failed", fieldError{Field: "type", Message: "must be browse"})
+	}
+	config.BrowseUsers = change(config.BrowseUsers, refs(request.Users))
+	config.BrowseGroups = change(config.BrowseGroups, refs(request.Groups))
+	return nil, nil
+}
+
+func (s *Server) topicConfigView(c *call, config *TopicConfig) object {
+	embedded := object{}
+	if environment, ok := find(s.state.Environments, config.Environment); ok {
+		embedded["environment"] = c.resource("environments", environment.Uid, object{"name": environment.Name, "shortName": environment.ShortName})
+	}
+	if topic, ok := find(s.state.Topics, config.Stream); ok {
+		embedded["stream"] = c.resource("streams", topic.Uid, object{"name": topic.Name})
+	}
+	return c.resource("stream_configs", config.Uid, object{
+		"partitions":    config.Partitions,
+		"retentionTime": config.RetentionTime,
+		"properties":    nonNilMap(config.Properties),
+		"_embedded":     embedded,
+	})
+}
@@ -0,0 +1,112 @@
+package fakeapi
+
+import "fmt"
+
+func (s *Server) routeTopics() {
+	s.route("GET /streams", func(c *call) (interface{}, error) {
+		return s.page(c, "streams", s.topicViews(c, s.state.Topics)), nil
+	})
+	s.route("POST /streams", func(c *call) (interface{}, error) {
+		topic := &Topic{Record: Record{Uid: newUID()}}
+		if err := s.applyTopic(c, topic); err != nil {
+			return nil, err
+		}
+		s.state.Topics = append(s.state.Topics, topic)
+		return created{s.topicView(c, topic)}, nil
+	})
+	s.route("GET /streams/{id}", func(c *call) (interface{}, error) {
+		topic, ok := find(s.state.Topics, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("stream", c.PathValue("id"))
+		}
+		return s.topicView(c, topic), nil
+	})
+	s.route("PATCH /streams/{id}", func(c *call) (interface{}, error) {
+		topic, ok := find(s.state.Topics, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("stream", c.PathValue("id"))
+		}
+		updated := *topic
+		updated.Properties = copyMap(topic.Properties)
+		if err := s.applyTopic(c, &updated); err != nil {
+			return nil, err
+		}
+		*topic = updated
+		return s.topicView(c, topic), nil
+	})
+	s.route("DELETE /streams/{id}", func(c *call) (interface{}, error) {
+		uid := c.PathValue("id")
+		if _, ok := find(s.state.Topics, uid); !ok {
+			return nil, notFound("stream", uid)
+		}
+		for _, config := range s.state.TopicConfigs {
+			if config.Stream == uid {
+				return nil, conflict(fmt.Sprintf("Stream %s can not be deleted, it is deployed with topic config %s", uid, config.Uid))
+			}
+		}
+		s.state.Topics = remove(s.state.Topics, uid)
+		return nil, nil
+	})
+	s.route("GET /streams/search/findByName", func(c *call) (interface{}, error) {
+		name := c.URL.Query().Get("name")
+		topics := filter(s.state.Topics, func(t *Topic) bool { return t.Name == name })
+		return s.page(c, "streams", s.topicViews(c, topics)), nil
+	})
+}
+
+// applyTopic applies the request to the topic and validates the result.
+func (s *Server) applyTopic(c *call, topic *Topic) error {
+	if err := apply(c.body, topic); err != nil {
+		return err
+	}
+	topic.KeySchema = ref(topic.KeySchema)
+	topic.ValueSchema = ref(topic.ValueSchema)
+	topic.Owners = ref(topic.Owners)
+	topic.Viewers = refs(topic.Viewers)
+	err := required(map[string]string{
+		"name":            topic.Name,
+		"keyType":         topic.KeyType,
+		"valueType":       topic.ValueType,
+		"owners":          topic.Owners,
+		"retentionPolicy": topic.RetentionPolicy,
+	})
+	if err != nil {
+		return err
+	}
+	for _, other := range s.state.Topics {
+		if other.Uid != topic.Uid && other.Name == topic.Name {
+			return conflict("Stream name already exists", fieldError{Field: "name", Message: "must be unique"})
+		}
+	}
+	for field, schema := range map[string]string{"keySchema": topic.KeySchema, "valueSchema": topic.ValueSchema} {
+		if _, ok := find(s.state.Schemas, schema); schema != "" && !ok {
+			return badRequest("Validation failed", fieldError{Field: field, Message: fmt.Sprintf("schema %s does not exist", schema)})
+		}
+	}
+	return s.requireGroup("owners", topic.Owners)
+}
+
+func (s *Server) topicViews(c *call, topics []*Topic) []interface{} {
+	views := []interface{}{}
+	for _, topic := range topics {
+		views = append(views, s.topicView(c, topic))
+	}
+	return views
+}
+
+func (s *Server) topicView(c *call, topic *Topic) object {
+	return c.resource("streams", topic.Uid, object{
+		"name":            topic.Name,
+		"description":     nullable(topic.Description),
+		"keyType":         topic.KeyType,
+		"valueType":       topic.ValueType,
+		"retentionPolicy": topic.RetentionPolicy,
+		"properties":      nonNilMap(topic.Properties),
+		"_embedded": object{
+			"keySchema":   s.schemaRef(c, topic.KeySchema),
+			"valueSchema": s.schemaRef(c, topic.ValueSchema),
+			"owners":      s.groupRef(c, topic.Owners),
+			"viewers":     s.groupRefs(c, topic.Viewers),
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package fakeapi
+
+import "strings"
+
+func (s *Server) routeUsers() {
+	s.route("GET /users", func(c *call) (interface{}, error) {
+		return s.page(c, "users", userViews(c, s.state.Users)), nil
+	})
+	s.route("GET /users/{id}", func(c *call) (interface{}, error) {
+		user, ok := find(s.state.Users, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("user", c.PathValue("id"))
+		}
+		return userView(c, user), nil
+	})
+	s.route("PATCH /users/{id}", func(c *call) (interface{}, error) {
+		user, ok := find(s.state.Users, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("user", c.PathValue("id"))
+		}
+		if err := apply(c.body, user); err != nil {
+			return nil, err
+		}
+		return userView(c, user), nil
+	})
+	s.route("PATCH /users/{id}/roles", func(c *call) (interface{}, error) {
+		user, ok := find(s.state.Users, c.PathValue("id"))
+		if !ok {
+			return nil, notFound("user", c.PathValue("id"))
+		}
+		var roles []Role
+		if err := c.decode(&roles); err != nil {
+			return nil, err
+		}
+		user.Roles = roles
+		return nil, nil
+	})
+	s.route("DELETE /users/{id}", func(c *call) (interface{}, error) {
+		if _, ok := find(s.state.Users, c.PathValue("id")); !ok {
+			return nil, notFound("user", c.PathValue("id"))
+		}
+		s.state.Users = remove(s.state.Users, c.PathValue("id"))
+		return nil, nil
+	})
+	s.route("GET /users/search/findByEmailAddress", func(c *call) (interface{}, error) {
+		email := c.URL.Query().Get("email")
+		users := filter(s.state.Users, func(u *User) bool { return strings.EqualFold(u.EmailAddress, email) })
+		return s.page(c, "users", userViews(c, users)), nil
+	})
+}
+
+func userViews(c *call, users []*User) []interface{} {
+	views := []interface{}{}
+	for _, user := range users {
+		views = append(views, userView(c, user))
+	}
+	return views
+}
+
+func userView(c *call, user *User) object {
+	roles := []interface{}{}
+	for _, role := range user.Roles {
+		roles = append(roles, object{"name": role.Name})
+	}
+	return c.resource("users", user.Uid, object{
+		"firstName":    user.FirstName,
+		"lastName":     user.LastName,
+		"middleName":   nullable(user.MiddleName),
+		"emailAddress": object{"email": user.EmailAddress},
+		"phoneNumber":  nullable(user.PhoneNumber),
+		"roles":        roles,
+	})
+}
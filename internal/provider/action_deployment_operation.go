@@ -0,0 +1,138 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/action/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ action.Action = &deploymentOperationAction{}
+
+// NewDeploymentStartAction starts a stopped application deployment.
+func NewDeploymentStartAction(provider AxualProvider) action.Action {
+	return &deploymentOperationAction{
+		provider:    provider,
+		name:        "start",
+		operations:  []string{"START"},
+		description: "Starts an Application Deployment and waits until it is running. A running deployment is left as it is",
+	}
+}
+
+// NewDeploymentStopAction stops a running application deployment.
+func NewDeploymentStopAction(provider AxualProvider) action.Action {
+	return &deploymentOperationAction{
+		provider:    provider,
+		name:        "stop",
+		operations:  []string{"STOP"},
+		description: "Stops an Application Deployment and waits until it is stopped. A stopped deployment is left as it is",
+	}
+}
+
+// NewDeploymentRestartAction stops an application deployment, if it is running, and starts it again.
+func NewDeploymentRestartAction(provider AxualProvider) action.Action {
+	return &deploymentOperationAction{
+		provider:    provider,
+		name:        "restart",
+		operations:  []string{"STOP", "START"},
+		description: "Stops an Application Deployment, if it is running, waits until it is stopped and starts it again, e.g. to pick up a rotated credential or a changed schema",
+	}
+}
+
+// deploymentOperationAction sends the operations to the deployment in order, waiting for the state of each
+// operation before sending the next one.
+type deploymentOperationAction struct {
+	provider    AxualProvider
+	name        string
+	operations  []string
+	description string
+}
+
+type deploymentOperationActionData struct {
+	Deployment types.String   `tfsdk:"deployment"`
+	Wait       types.Bool     `tfsdk:"wait"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (a *deploymentOperationAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_" + a.name
+}
+
+func (a *deploymentOperationAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: a.description + ". Invoke it with `terraform apply -invoke` or from an `action_trigger` in the lifecycle of a resource. Requires Terraform 1.14 or later. Read more: https://docs.axual.io/axual/2026.1/self-service/application-management.html",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Id of the Application Deployment, e.g. `axual_application_deployment.example.id`",
+			},
+			"wait": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Wait until the deployment reached the state of the action, failing when a started deployment fails. A restart always waits for the deployment to stop before starting it (defaults to true)",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (a *deploymentOperationAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data deploymentOperationActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Invoke, defaultDeploymentTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	id := data.Deployment.ValueString()
+	wait := data.Wait.IsNull() || data.Wait.ValueBool()
+	for i, operation := range a.operations {
+		state := deploymentOperationState(operation)
+		sendProgress(resp, fmt.Sprintf("Sending %s to application deployment %s", operation, id))
+		err := a.provider.client.OperateApplicationDeployment(ctx, id, operation, webclient.ApplicationDeploymentOperationRequest{Action: operation})
+		if errors.Is(err, webclient.InvalidDeploymentStateError) {
+			tflog.Info(ctx, "Application deployment is already in the state of the operation", map[string]interface{}{"deployment": id, "operation": operation})
+			sendProgress(resp, fmt.Sprintf("Application deployment %s is already %s", id, state))
+		} else if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to %s application deployment %s, got error: %s", strings.ToLower(operation), id, err))
+			return
+		}
+
+		// A deployment only starts once it is stopped, so every operation but the last one is always awaited.
+		if !wait && i == len(a.operations)-1 {
+			return
+		}
+		sendProgress(resp, fmt.Sprintf("Waiting for application deployment %s to be %s", id, state))
+		if err := a.provider.client.WaitForApplicationDeploymentOperation(ctx, id, operation); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Application deployment %s did not become %s: %s", id, state, err))
+			return
+		}
+		sendProgress(resp, fmt.Sprintf("Application deployment %s is %s", id, state))
+	}
+}
+
+// deploymentOperationState names the state an operation brings a deployment to.
+func deploymentOperationState(operation string) string {
+	if operation == "STOP" {
+		return "stopped"
+	}
+	return "running"
+}
+
+// sendProgress reports a message to Terraform while the action runs.
+func sendProgress(resp *action.InvokeResponse, message string) {
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: message})
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type fakeDeploymentOperationsAPI struct {
+	fakeAPI
+	running bool
+	calls   []string
+}
+
+func (f *fakeDeploymentOperationsAPI) OperateApplicationDeployment(ctx context.Context, id string, action string, data webclient.ApplicationDeploymentOperationRequest) error {
+	f.calls = append(f.calls, action)
+	if f.running == (action == "START") {
+		return fmt.Errorf("%w: already", webclient.InvalidDeploymentStateError)
+	}
+	f.running = action == "START"
+	return nil
+}
+
+func (f *fakeDeploymentOperationsAPI) WaitForApplicationDeploymentOperation(ctx context.Context, id string, action string) error {
+	f.calls = append(f.calls, "WAIT "+action)
+	return nil
+}
+
+func TestDeploymentOperationActionInvoke(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		newAction func(AxualProvider) action.Action
+		running   bool
+		wait      *bool
+		wantCalls []string
+	}{
+		{
+			desc:      "start waits until the deployment runs",
+			newAction: NewDeploymentStartAction,
+			wantCalls: []string{"START", "WAIT START"},
+		},
+		{
+			desc:      "start of a running deployment still waits for it",
+			newAction: NewDeploymentStartAction,
+			running:   true,
+			wantCalls: []string{"START", "WAIT START"},
+		},
+		{
+			desc:      "stop without waiting",
+			newAction: NewDeploymentStopAction,
+			running:   true,
+			wait:      new(bool),
+			wantCalls: []string{"STOP"},
+		},
+		{
+			desc:      "restart stops and starts a running deployment",
+			newAction: NewDeploymentRestartAction,
+			running:   true,
+			wantCalls: []string{"STOP", "WAIT STOP", "START", "WAIT START"},
+		},
+		{
+			desc:      "restart of a stopped deployment waits for the stop before starting",
+			newAction: NewDeploymentRestartAction,
+			wait:      new(bool),
+			wantCalls: []string{"STOP", "WAIT STOP", "START"},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			api := &fakeDeploymentOperationsAPI{running: c.running}
+			a := c.newAction(testProvider(api))
+			schemaResp := &action.SchemaResponse{}
+			a.Schema(context.Background(), action.SchemaRequest{}, schemaResp)
+			s := schemaResp.Schema
+			config := map[string]tftypes.Value{
+				"deployment": tftypes.NewValue(tftypes.String, "deployment-uid"),
+			}
+			if c.wait != nil {
+				config["wait"] = tftypes.NewValue(tftypes.Bool, *c.wait)
+			}
+			resp := &action.InvokeResponse{}
+			a.Invoke(context.Background(), action.InvokeRequest{Config: tfsdk.Config{Schema: s, Raw: objectValue(t, s, config)}}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if !reflect.DeepEqual(api.calls, c.wantCalls) {
+				t.Errorf("expected calls %v, got %v", c.wantCalls, api.calls)
+			}
+		})
+	}
+}
@@ -143,7 +143,7 @@ func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		searchParam = "shortName"
 	}
 
-	appResponse, err := d.provider.client.GetApplicationByNameOrShortName(params)
+	appResponse, err := d.provider.client.GetApplicationByNameOrShortName(ctx, params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application by %s: '%s', got error: %s", searchParam, searchValue, err))
 		return
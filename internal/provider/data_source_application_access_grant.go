@@ -89,7 +89,7 @@ func (d applicationAccessGrantDataSource) Read(ctx context.Context, req datasour
 		AccessType:    data.AccessType.ValueString(),
 	}
 
-	applicationAccessGrant, err := d.provider.client.GetApplicationAccessGrantsByAttributes(accessGrantRequest)
+	applicationAccessGrant, err := d.provider.client.GetApplicationAccessGrantsByAttributes(ctx, accessGrantRequest)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application access grant, got error: %s", err))
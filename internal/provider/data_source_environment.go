@@ -149,13 +149,13 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 	var err error
 
 	if data.ShortName.ValueString() == "" {
-		environmentResponse, err = d.provider.client.GetEnvironmentByName(data.Name.ValueString())
+		environmentResponse, err = d.provider.client.GetEnvironmentByName(ctx, data.Name.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read environment by name, got error: %s", err))
 			return
 		}
 	} else {
-		environmentResponse, err = d.provider.client.GetEnvironmentByShortName(data.ShortName.ValueString())
+		environmentResponse, err = d.provider.client.GetEnvironmentByShortName(ctx, data.ShortName.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read environment by short_name, got error: %s", err))
 			return
@@ -171,7 +171,7 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	environment, err := d.provider.client.GetEnvironment(environmentResponse.Embedded.Environments[0].Uid)
+	environment, err := d.provider.client.GetEnvironment(ctx, environmentResponse.Embedded.Environments[0].Uid)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read environment, got error: %s", err))
 		return
@@ -82,7 +82,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	groupByName, err := d.provider.client.GetGroupByName(data.Name.ValueString())
+	groupByName, err := d.provider.client.GetGroupByName(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group by name, got error: %s", err))
 		return
@@ -97,7 +97,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	group, err2 := d.provider.client.GetGroup(groupByName.Embedded.Groups[0].Uid)
+	group, err2 := d.provider.client.GetGroup(ctx, groupByName.Embedded.Groups[0].Uid)
 	if err2 != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group, got error: %s", err2))
 		return
@@ -107,11 +107,11 @@ func (d *instanceDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	if data.ShortName.ValueString() == "" {
 		searchValue = data.Name.ValueString()
-		instanceResponse, err = d.provider.client.GetInstanceByName(searchValue)
+		instanceResponse, err = d.provider.client.GetInstanceByName(ctx, searchValue)
 	} else {
 		searchValue = data.ShortName.ValueString()
 		searchParam = "shortName"
-		instanceResponse, err = d.provider.client.GetInstanceByShortName(searchValue)
+		instanceResponse, err = d.provider.client.GetInstanceByShortName(ctx, searchValue)
 	}
 
 	if err != nil {
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &platformDataSource{}
+
+func NewPlatformDataSource(provider AxualProvider) datasource.DataSource {
+	return &platformDataSource{
+		provider: provider,
+	}
+}
+
+type platformDataSource struct {
+	provider AxualProvider
+}
+
+type platformDataSourceData struct {
+	Name      types.String `tfsdk:"name"`
+	Version   types.String `tfsdk:"version"`
+	BuildTime types.String `tfsdk:"build_time"`
+	Features  types.Set    `tfsdk:"features"`
+}
+
+func (d *platformDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platform"
+}
+
+func (d *platformDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Platform data source: the version of the Platform Manager behind the API and the features it supports. When the API does not expose its build info, the version is empty and all features are reported as supported.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Build name of the Platform Manager",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Platform Manager version, e.g. `15.0.2`",
+				Computed:            true,
+			},
+			"build_time": schema.StringAttribute{
+				MarkdownDescription: "Time the Platform Manager was built",
+				Computed:            true,
+			},
+			"features": schema.SetAttribute{
+				MarkdownDescription: "Features of the provider that the platform supports, such as `instance_search_by_name` and `principal_rotation`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *platformDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data platformDataSourceData
+
+	platform, err := d.provider.client.DetectPlatform(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the platform build info, got error: %s", err))
+		return
+	}
+
+	mapPlatformDataSourceResponseToData(&data, platform, d.provider.client)
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func mapPlatformDataSourceResponseToData(data *platformDataSourceData, platform *webclient.PlatformInfo, client webclient.PlatformAPI) {
+	data.Name = types.StringValue(platform.Name)
+	data.Version = types.StringValue(platform.Version)
+	data.BuildTime = types.StringValue(platform.BuildTime)
+
+	var features []attr.Value
+	for _, feature := range webclient.Features {
+		if client.Supports(feature) {
+			features = append(features, types.StringValue(feature.Name))
+		}
+	}
+	data.Features = types.SetValueMust(types.StringType, features)
+}
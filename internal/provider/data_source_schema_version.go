@@ -82,7 +82,7 @@ func (d *schemaVersionDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	axualSchema, err := d.provider.client.GetSchemaByName(data.FullName.ValueString())
+	axualSchema, err := d.provider.client.GetSchemaByName(ctx, data.FullName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read axualSchema version, got error: %s", err))
 		return
@@ -92,7 +92,7 @@ func (d *schemaVersionDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	sv, err2 := d.provider.client.GetSchemaVersionsBySchema(axualSchema.Embedded.Schemas[0].Links.Self.Href)
+	sv, err2 := d.provider.client.GetSchemaVersionsBySchema(ctx, axualSchema.Embedded.Schemas[0].Links.Self.Href)
 
 	if err2 != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read axualSchema version, got error: %s", err2))
@@ -111,7 +111,7 @@ func (d *topicDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	topicByName, err := d.provider.client.GetTopicByName(data.Name.ValueString())
+	topicByName, err := d.provider.client.GetTopicByName(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic by name, got error: %s", err))
 		return
@@ -126,7 +126,7 @@ func (d *topicDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	topic, err := d.provider.client.GetTopic(topicByName.Embedded.Topics[0].Uid)
+	topic, err := d.provider.client.GetTopic(ctx, topicByName.Embedded.Topics[0].Uid)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic, got error: %s", err))
 		return
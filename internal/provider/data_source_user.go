@@ -76,7 +76,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	usersResponse, err := d.provider.client.FindUserByEmail(data.Email.ValueString())
+	usersResponse, err := d.provider.client.FindUserByEmail(ctx, data.Email.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find user by email, got error: %s", err))
 		return
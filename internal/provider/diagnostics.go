@@ -0,0 +1,80 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// addAPIError adds an error diagnostic for a failed API call. Validation errors the API reports
+// for one of the given attributes are attached to that attribute, so Terraform points at the
+// offending argument in the configuration. Attributes are Terraform attribute names; the API field
+// name is derived by camel-casing it, or given explicitly as "attribute:apiField" (e.g. "topic:stream").
+func addAPIError(diags *diag.Diagnostics, summary string, detail string, err error, attributes ...string) {
+	apiErr, ok := webclient.AsAPIError(err)
+	if !ok {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	fields := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		name, apiField, found := strings.Cut(attribute, ":")
+		if !found {
+			apiField = camelCase(name)
+		}
+		fields[apiField] = name
+	}
+
+	unmatched := len(apiErr.FieldErrors) == 0
+	for _, fieldErr := range apiErr.FieldErrors {
+		// Nested fields such as "properties.segment.ms" belong to their top-level attribute.
+		field, _, _ := strings.Cut(fieldErr.Field, ".")
+		field, _, _ = strings.Cut(field, "[")
+		if name, ok := fields[field]; ok {
+			diags.AddAttributeError(path.Root(name), summary, fieldErr.Message)
+		} else {
+			unmatched = true
+		}
+	}
+	if unmatched {
+		diags.AddError(summary, detail+apiErrorHint(apiErr))
+	}
+}
+
+// apiErrorHint explains the error classes that are caused by the provider configuration or
+// by concurrent changes rather than by the resource arguments.
+func apiErrorHint(apiErr *webclient.APIError) string {
+	switch {
+	case apiErr.IsUnauthorized():
+		return "\n\nThe API rejected the provider credentials. Check the authentication settings of the provider."
+	case apiErr.IsForbidden():
+		return "\n\nThe authenticated user is not allowed to perform this action. Check the roles of the user and the owners of the resource."
+	case apiErr.IsConflict():
+		return "\n\nThe request conflicts with the current state of the resource on the platform. It may have been changed outside of Terraform; refresh and try again."
+	}
+	return ""
+}
+
+// addUnsupportedFeatureError adds an error diagnostic for an action the connected platform is too old for.
+func addUnsupportedFeatureError(diags *diag.Diagnostics, attribute path.Path, action string, err error) {
+	diags.AddAttributeError(
+		attribute,
+		"Unsupported Platform Version",
+		fmt.Sprintf("%s is not supported by the connected platform: %s. Upgrade the platform, or recreate the resource instead.", action, err),
+	)
+}
+
+// camelCase converts a Terraform attribute name like "short_name" to the API field name "shortName".
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
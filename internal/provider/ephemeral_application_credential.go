@@ -0,0 +1,238 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ ephemeral.EphemeralResource = &applicationCredentialEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &applicationCredentialEphemeralResource{}
+
+// createdCredentialKey is the key of the private data holding the credential to delete when the ephemeral resource is closed.
+const createdCredentialKey = "created_credential"
+
+func NewApplicationCredentialEphemeralResource(provider AxualProvider) ephemeral.EphemeralResource {
+	return &applicationCredentialEphemeralResource{
+		provider: provider,
+	}
+}
+
+type applicationCredentialEphemeralResource struct {
+	provider AxualProvider
+}
+
+type applicationCredentialEphemeralResourceData struct {
+	Id            types.String   `tfsdk:"id"`
+	ApplicationId types.String   `tfsdk:"application"`
+	EnvironmentId types.String   `tfsdk:"environment"`
+	Target        types.String   `tfsdk:"target"`
+	UserName      types.String   `tfsdk:"username"`
+	Password      types.String   `tfsdk:"password"`
+	Clusters      types.String   `tfsdk:"clusters"`
+	Description   types.String   `tfsdk:"description"`
+	AuthProvider  types.String   `tfsdk:"auth_provider"`
+	Types         []types.String `tfsdk:"types"`
+	DeleteOnClose types.Bool     `tfsdk:"delete_on_close"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *applicationCredentialEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_credential"
+}
+
+func (r *applicationCredentialEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates an Application Credential (SASL) for an Application in an Environment, or fetches an existing one, without storing the password in the state or plan. Use it to pass the credential to other providers, e.g. a secrets manager. Requires Terraform 1.10 or later. Terraform opens the ephemeral resource in every plan and apply, so without `username` every run creates a credential. By default it is deleted at the end of the run, so a password stored elsewhere, e.g. in a secrets manager, stops working; with `delete_on_close = false` it is kept, and every plan and apply leaves a credential that the provider never removes. Read more: https://docs.axual.io/axual/2026.1/self-service/application-management.html#configuring-application-securityauthentication",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Application Credential Id",
+			},
+			"application": schema.StringAttribute{
+				MarkdownDescription: "A valid Id of an existing application",
+				Required:            true,
+			},
+			"environment": schema.StringAttribute{
+				MarkdownDescription: "A valid Id of an existing environment",
+				Required:            true,
+			},
+			"target": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The authentication credential provider (e.g., Apache Kafka, Schema Registry).",
+				Validators: []validator.String{
+					stringvalidator.OneOf("KAFKA", "SCHEMA_REGISTRY"),
+				},
+			},
+			"username": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Username of an existing credential to fetch instead of creating a new one. The password of an existing credential cannot be read from the API, so `password` is null when fetching",
+			},
+			"password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password of a created credential. It is only available while Terraform runs and is never stored in the state or plan",
+			},
+			"delete_on_close": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Delete the created credential when Terraform closes the ephemeral resource at the end of the plan or apply. When true, a password stored elsewhere, e.g. in a secrets manager, no longer works after the run. When false, every plan and apply creates a credential that the provider never removes. A credential created by a failing run is always deleted. Not allowed with `username` (defaults to true)",
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("username")),
+				},
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Description information for the credentials.",
+			},
+			"clusters": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Cluster information for the credentials.",
+			},
+			"auth_provider": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The authentication provider (e.g., Apache Kafka, Schema Registry). Only returned when the credential is created",
+			},
+			"types": schema.ListAttribute{
+				MarkdownDescription: "List of authentication types.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (r *applicationCredentialEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data applicationCredentialEphemeralResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Open, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	username := data.UserName.ValueString()
+	if data.UserName.IsNull() || data.UserName.IsUnknown() {
+		request := webclient.ApplicationCredentialCreateRequest{
+			ApplicationId: data.ApplicationId.ValueString(),
+			EnvironmentId: data.EnvironmentId.ValueString(),
+			Target:        data.Target.ValueString(),
+		}
+		credential, err := r.provider.client.CreateApplicationCredential(ctx, request)
+		if err != nil {
+			resp.Diagnostics.AddError("CREATE request error for application credential ephemeral resource", fmt.Sprintf("Error message: %s", err))
+			return
+		}
+		username = credential.AuthData.Username
+		data.UserName = types.StringValue(username)
+		data.Password = types.StringValue(credential.AuthData.Password)
+		data.Clusters = types.StringValue(credential.AuthData.Clusters)
+		data.AuthProvider = types.StringValue(credential.AuthData.Provider)
+		tflog.Info(ctx, "Created an application credential for the ephemeral resource", map[string]interface{}{"username": username})
+		// Terraform only calls Close after Open succeeds, so a credential created by a failing Open is deleted here,
+		// whatever delete_on_close says: nothing received its password.
+		defer func() {
+			if resp.Diagnostics.HasError() {
+				r.deleteCredential(context.WithoutCancel(ctx), webclient.ApplicationCredentialDeleteRequest{
+					ApplicationId: request.ApplicationId,
+					EnvironmentId: request.EnvironmentId,
+					Target:        request.Target,
+					Configs:       webclient.NameConfig{Username: username},
+				}, &resp.Diagnostics)
+			}
+		}()
+
+		deleteRequest, err := credentialDeleteRequest(data, request)
+		if err != nil {
+			resp.Diagnostics.AddError("Error saving the credential to delete on close", fmt.Sprintf("Error message: %s", err))
+			return
+		}
+		if deleteRequest != nil {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, createdCredentialKey, deleteRequest)...)
+		}
+	} else {
+		data.Password = types.StringNull()
+		data.AuthProvider = types.StringNull()
+	}
+
+	// The Create API response does not include the credential ID, so the credential is looked up by username.
+	credentials, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(ctx, data.ApplicationId.ValueString(), data.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error querying for Application Credential for this application and environment", fmt.Sprintf("Error message: %s", err.Error()))
+		return
+	}
+	found := false
+	for _, credential := range credentials {
+		if credential.Username == username {
+			data.Id = types.StringValue(credential.ID)
+			data.Description = types.StringValue(credential.Description)
+			data.Types = convertAuthTypeListToTypesStringList(credential.Types)
+			if data.Clusters.IsNull() || data.Clusters.IsUnknown() {
+				data.Clusters = types.StringValue(credential.Metadata.Clusters)
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		resp.Diagnostics.AddError("Application Credential not found", fmt.Sprintf("No credential with username %q exists for application %s in environment %s", username, data.ApplicationId.ValueString(), data.EnvironmentId.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// credentialDeleteRequest returns the request deleting the created credential when the ephemeral resource is closed,
+// or nil when delete_on_close is false. Terraform opens the ephemeral resource in every plan and apply, so created
+// credentials are deleted unless the configuration explicitly keeps them.
+func credentialDeleteRequest(data applicationCredentialEphemeralResourceData, request webclient.ApplicationCredentialCreateRequest) ([]byte, error) {
+	if !data.DeleteOnClose.IsNull() && !data.DeleteOnClose.ValueBool() {
+		return nil, nil
+	}
+	return json.Marshal(webclient.ApplicationCredentialDeleteRequest{
+		ApplicationId: request.ApplicationId,
+		EnvironmentId: request.EnvironmentId,
+		Target:        request.Target,
+		Configs:       webclient.NameConfig{Username: data.UserName.ValueString()},
+	})
+}
+
+func (r *applicationCredentialEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	deleteRequestJSON, diags := req.Private.GetKey(ctx, createdCredentialKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(deleteRequestJSON) == 0 {
+		return
+	}
+
+	var deleteRequest webclient.ApplicationCredentialDeleteRequest
+	if err := json.Unmarshal(deleteRequestJSON, &deleteRequest); err != nil {
+		resp.Diagnostics.AddError("Error reading the credential to delete on close", fmt.Sprintf("Error message: %s", err))
+		return
+	}
+	r.deleteCredential(ctx, deleteRequest, &resp.Diagnostics)
+}
+
+func (r *applicationCredentialEphemeralResource) deleteCredential(ctx context.Context, deleteRequest webclient.ApplicationCredentialDeleteRequest, diags *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	if err := r.provider.client.DeleteApplicationCredential(ctx, deleteRequest); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete application credential %s, got error: %s", deleteRequest.Configs.Username, err))
+	}
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type fakeCredentialsAPI struct {
+	fakeAPI
+	credentials []webclient.ApplicationCredentialFindByApplicationAndEnvironmentResponse
+	created     []webclient.ApplicationCredentialCreateRequest
+	deleted     []webclient.ApplicationCredentialDeleteRequest
+	// unlisted leaves created credentials out of the credentials found afterwards.
+	unlisted bool
+}
+
+func (f *fakeCredentialsAPI) CreateApplicationCredential(ctx context.Context, request webclient.ApplicationCredentialCreateRequest) (webclient.ApplicationCredentialResponse, error) {
+	f.created = append(f.created, request)
+	credential := webclient.ApplicationCredentialFindByApplicationAndEnvironmentResponse{ID: "credential-uid", Username: "created-user", Description: "Created by Terraform"}
+	credential.Types = []webclient.AuthType{{Type: "SCRAM_SHA_512"}}
+	if !f.unlisted {
+		f.credentials = append(f.credentials, credential)
+	}
+	response := webclient.ApplicationCredentialResponse{}
+	response.AuthData.Username = "created-user"
+	response.AuthData.Password = "created-password"
+	response.AuthData.Clusters = "cluster-a"
+	response.AuthData.Provider = "KAFKA"
+	return response, nil
+}
+
+func (f *fakeCredentialsAPI) FindApplicationCredentialByApplicationAndEnvironment(ctx context.Context, application string, environment string) ([]webclient.ApplicationCredentialFindByApplicationAndEnvironmentResponse, error) {
+	return f.credentials, nil
+}
+
+func (f *fakeCredentialsAPI) DeleteApplicationCredential(ctx context.Context, request webclient.ApplicationCredentialDeleteRequest) error {
+	f.deleted = append(f.deleted, request)
+	return nil
+}
+
+func TestApplicationCredentialEphemeralResourceOpen(t *testing.T) {
+	existing := webclient.ApplicationCredentialFindByApplicationAndEnvironmentResponse{ID: "existing-uid", Username: "existing-user", Description: "Existing"}
+	existing.Metadata.Clusters = "cluster-b"
+	testCases := []struct {
+		desc          string
+		username      string
+		deleteOnClose bool
+		unlisted      bool
+		wantCreated   int
+		wantDeleted   int
+		wantID        string
+		wantUsername  string
+		wantPassword  types.String
+		wantClusters  string
+		wantError     bool
+	}{
+		{
+			desc:         "a new credential is created with its password",
+			wantCreated:  1,
+			wantID:       "credential-uid",
+			wantUsername: "created-user",
+			wantPassword: types.StringValue("created-password"),
+			wantClusters: "cluster-a",
+		},
+		{
+			desc:         "an existing credential is fetched without its password",
+			username:     "existing-user",
+			wantID:       "existing-uid",
+			wantUsername: "existing-user",
+			wantPassword: types.StringNull(),
+			wantClusters: "cluster-b",
+		},
+		{
+			desc:      "fetching an unknown username fails",
+			username:  "unknown-user",
+			wantError: true,
+		},
+		{
+			desc:        "a created credential that is not found is deleted",
+			unlisted:    true,
+			wantCreated: 1,
+			wantDeleted: 1,
+			wantError:   true,
+		},
+		{
+			// The response has no private state to hold the credential to delete on close.
+			desc:          "a created credential that cannot be deleted on close is deleted",
+			deleteOnClose: true,
+			wantCreated:   1,
+			wantDeleted:   1,
+			wantError:     true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			api := &fakeCredentialsAPI{credentials: []webclient.ApplicationCredentialFindByApplicationAndEnvironmentResponse{existing}, unlisted: c.unlisted}
+			r := NewApplicationCredentialEphemeralResource(testProvider(api))
+			schemaResp := &ephemeral.SchemaResponse{}
+			r.Schema(context.Background(), ephemeral.SchemaRequest{}, schemaResp)
+			s := schemaResp.Schema
+			config := map[string]tftypes.Value{
+				"application": tftypes.NewValue(tftypes.String, "app-uid"),
+				"environment": tftypes.NewValue(tftypes.String, "env-uid"),
+				"target":      tftypes.NewValue(tftypes.String, "KAFKA"),
+			}
+			if c.username != "" {
+				config["username"] = tftypes.NewValue(tftypes.String, c.username)
+			} else {
+				// The response has no private state to hold the credential to delete, see TestCredentialDeleteRequest.
+				config["delete_on_close"] = tftypes.NewValue(tftypes.Bool, c.deleteOnClose)
+			}
+			resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+			r.Open(context.Background(), ephemeral.OpenRequest{Config: tfsdk.Config{Schema: s, Raw: objectValue(t, s, config)}}, resp)
+
+			if len(api.deleted) != c.wantDeleted {
+				t.Errorf("expected %d deleted credentials, got %+v", c.wantDeleted, api.deleted)
+			}
+			for _, deleted := range api.deleted {
+				if deleted.Configs.Username != "created-user" || deleted.ApplicationId != "app-uid" || deleted.EnvironmentId != "env-uid" || deleted.Target != "KAFKA" {
+					t.Errorf("unexpected delete request %+v", deleted)
+				}
+			}
+			if c.wantError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if len(api.created) != c.wantCreated {
+				t.Errorf("expected %d created credentials, got %+v", c.wantCreated, api.created)
+			}
+			var data applicationCredentialEphemeralResourceData
+			resp.Diagnostics.Append(resp.Result.Get(context.Background(), &data)...)
+			if data.Id.ValueString() != c.wantID || data.UserName.ValueString() != c.wantUsername || data.Clusters.ValueString() != c.wantClusters {
+				t.Errorf("expected credential %s of %s on %s, got %+v", c.wantID, c.wantUsername, c.wantClusters, data)
+			}
+			var password types.String
+			resp.Diagnostics.Append(resp.Result.GetAttribute(context.Background(), path.Root("password"), &password)...)
+			if !password.Equal(c.wantPassword) {
+				t.Errorf("expected password %s, got %s", c.wantPassword, password)
+			}
+		})
+	}
+}
+
+func TestCredentialDeleteRequest(t *testing.T) {
+	request := webclient.ApplicationCredentialCreateRequest{ApplicationId: "app-uid", EnvironmentId: "env-uid", Target: "KAFKA"}
+	testCases := []struct {
+		desc          string
+		deleteOnClose types.Bool
+		wantDelete    bool
+	}{
+		{
+			desc:          "created credentials are deleted by default",
+			deleteOnClose: types.BoolNull(),
+			wantDelete:    true,
+		},
+		{
+			desc:          "created credentials are deleted when requested",
+			deleteOnClose: types.BoolValue(true),
+			wantDelete:    true,
+		},
+		{
+			desc:          "created credentials are kept when delete_on_close is false",
+			deleteOnClose: types.BoolValue(false),
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			data := applicationCredentialEphemeralResourceData{UserName: types.StringValue("created-user"), DeleteOnClose: c.deleteOnClose}
+			deleteRequestJSON, err := credentialDeleteRequest(data, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !c.wantDelete {
+				if deleteRequestJSON != nil {
+					t.Fatalf("expected the credential to be kept, got %s", deleteRequestJSON)
+				}
+				return
+			}
+			var deleteRequest webclient.ApplicationCredentialDeleteRequest
+			if err := json.Unmarshal(deleteRequestJSON, &deleteRequest); err != nil {
+				t.Fatal(err)
+			}
+			if deleteRequest.Configs.Username != "created-user" || deleteRequest.ApplicationId != "app-uid" || deleteRequest.EnvironmentId != "env-uid" || deleteRequest.Target != "KAFKA" {
+				t.Errorf("unexpected delete request %+v", deleteRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeAPI is the base of the fakes of the unit tests. It embeds the AxualAPI interface, so a fake
+// only implements the methods its test calls; any other call panics on the nil interface.
+type fakeAPI struct {
+	webclient.AxualAPI
+}
+
+func (f *fakeAPI) BaseURL() string {
+	return "https://platform.local/api"
+}
+
+// testProvider returns a configured provider using the given fake.
+func testProvider(client webclient.AxualAPI) AxualProvider {
+	return AxualProvider{client: client, injected: true, configured: true, version: "test"}
+}
+
+// resourceSchema returns the schema of the resource.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("invalid schema: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// objectValue returns a value of the schema with the given attributes; the other attributes and blocks are null.
+func objectValue(t *testing.T, s interface{ Type() attr.Type }, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	for name := range values {
+		if _, ok := objectType.AttributeTypes[name]; !ok {
+			t.Fatalf("unknown attribute %q", name)
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func testPlan(t *testing.T, s schema.Schema, values map[string]tftypes.Value) tfsdk.Plan {
+	return tfsdk.Plan{Schema: s, Raw: objectValue(t, s, values)}
+}
+
+func testConfig(t *testing.T, s schema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	return tfsdk.Config{Schema: s, Raw: objectValue(t, s, values)}
+}
+
+func testState(t *testing.T, s schema.Schema, values map[string]tftypes.Value) tfsdk.State {
+	return tfsdk.State{Schema: s, Raw: objectValue(t, s, values)}
+}
+
+// emptyState returns the state a response starts with.
+func emptyState(s schema.Schema) tfsdk.State {
+	return tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+}
+
+func stringSet(values ...string) tftypes.Value {
+	elements := make([]tftypes.Value, len(values))
+	for i, value := range values {
+		elements[i] = tftypes.NewValue(tftypes.String, value)
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseImportIdFunction{}
+
+// uidPattern matches the uids of the platform, which are the import ids of the resources.
+var uidPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+var importIdAttributeTypes = map[string]attr.Type{
+	"collection": types.StringType,
+	"id":         types.StringType,
+}
+
+func NewParseImportIdFunction() function.Function {
+	return &parseImportIdFunction{}
+}
+
+type parseImportIdFunction struct{}
+
+func (f *parseImportIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+func (f *parseImportIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the import id in a link to a resource",
+		MarkdownDescription: "Returns the uid used to import a resource from a link to it, such as the `_links.self.href` of an API response or the address of its page in the Self-Service UI, e.g. `https://platform.example.com/api/topics/2e6d6c8a3f0e4e4d9b6f2d4b1c7a9e10`. The result is an object with the `id` and the `collection` it belongs to, such as `topics`; `collection` is null for a bare uid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "link",
+				MarkdownDescription: "Link to a resource, or its uid",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: importIdAttributeTypes,
+		},
+	}
+}
+
+func (f *parseImportIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var link string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &link))
+	if resp.Error != nil {
+		return
+	}
+
+	collection, id, err := parseImportId(link)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	collectionValue := types.StringNull()
+	if collection != "" {
+		collectionValue = types.StringValue(collection)
+	}
+	result, diags := types.ObjectValue(importIdAttributeTypes, map[string]attr.Value{
+		"collection": collectionValue,
+		"id":         types.StringValue(id),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// parseImportId returns the last uid in the path of the link and the path segment before it, which is the
+// collection of the resource, such as topics or application_principals. Segments after the uid, such as
+// /edit in the Self-Service UI, are ignored.
+func parseImportId(link string) (string, string, error) {
+	link = strings.TrimSpace(link)
+	if uidPattern.MatchString(link) {
+		return "", link, nil
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid link %q: %s", link, err)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		if uidPattern.MatchString(segments[i]) {
+			return segments[i-1], segments[i], nil
+		}
+	}
+	return "", "", fmt.Errorf("no uid found in %q", link)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &principalFromPemFunction{}
+
+func NewPrincipalFromPemFunction() function.Function {
+	return &principalFromPemFunction{}
+}
+
+type principalFromPemFunction struct{}
+
+func (f *principalFromPemFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "principal_from_pem"
+}
+
+func (f *principalFromPemFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the principal of an SSL certificate",
+		MarkdownDescription: "Returns the subject distinguished name of the first certificate in a PEM chain in RFC 2253 format, such as `CN=Log Scraper,OU=Team Awesome,O=Axual,C=NL`. This is the principal the platform derives from the certificate of an `axual_application_principal`, e.g. for ACLs or `principal` filters.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pem",
+				MarkdownDescription: "PEM encoded certificate or certificate chain, e.g. `file(\"certs/app.pem\")`. Other PEM blocks, such as a private key, are skipped",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *principalFromPemFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var chain string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &chain))
+	if resp.Error != nil {
+		return
+	}
+
+	principal, err := principalFromPem(chain)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, principal))
+}
+
+// principalFromPem returns the subject of the first certificate in the chain. The raw subject is used instead of
+// the parsed name, so the attributes keep their order and unknown attributes, such as an e-mail address, are
+// written as their OID with the hex encoded value, like the platform does.
+func principalFromPem(chain string) (string, error) {
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return "", fmt.Errorf("no PEM certificate found")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("invalid certificate: %w", err)
+		}
+		var subject pkix.RDNSequence
+		if _, err := asn1.Unmarshal(certificate.RawSubject, &subject); err != nil {
+			return "", fmt.Errorf("invalid certificate subject: %w", err)
+		}
+		return subject.String(), nil
+	}
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &topicFullNameFunction{}
+
+// defaultTopicPattern is the pattern topic names are resolved with on the Kafka cluster when the instance does not configure another one.
+const defaultTopicPattern = "{instance}-{environment}-{topic}"
+
+// topicPatternPlaceholder matches the placeholders of a topic pattern.
+var topicPatternPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+func NewTopicFullNameFunction() function.Function {
+	return &topicFullNameFunction{}
+}
+
+type topicFullNameFunction struct{}
+
+func (f *topicFullNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "topic_full_name"
+}
+
+func (f *topicFullNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the name of a topic on the Kafka cluster",
+		MarkdownDescription: "Returns the name of a topic on the Kafka cluster, which the platform resolves from the short names of the instance and environment and the topic name, e.g. `dta-dev-orders`. Clients that connect without the Axual client libraries, such as a plain Kafka client or another Terraform provider, use this name.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "instance",
+				MarkdownDescription: "Short name of the instance, e.g. `data.axual_instance.dta.short_name`",
+			},
+			function.StringParameter{
+				Name:                "environment",
+				MarkdownDescription: "Short name of the environment, e.g. `axual_environment.dev.short_name`",
+			},
+			function.StringParameter{
+				Name:                "topic",
+				MarkdownDescription: "Name of the topic, e.g. `axual_topic.orders.name`",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "pattern",
+			MarkdownDescription: "Pattern of the topic names of the instance, when it is not `" + defaultTopicPattern + "`, such as `acme-{instance}-{environment}-{topic}` on a platform that adds the tenant. Supports the placeholders `{instance}`, `{environment}` and `{topic}`",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *topicFullNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var instance, environment, topic string
+	var patterns []string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &instance, &environment, &topic, &patterns))
+	if resp.Error != nil {
+		return
+	}
+	if len(patterns) > 1 {
+		resp.Error = function.NewArgumentFuncError(3, "only one pattern can be given")
+		return
+	}
+	pattern := defaultTopicPattern
+	if len(patterns) == 1 {
+		pattern = patterns[0]
+	}
+
+	name, err := topicFullName(pattern, instance, environment, topic)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(3, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, name))
+}
+
+// topicFullName fills in the placeholders of the pattern.
+func topicFullName(pattern string, instance string, environment string, topic string) (string, error) {
+	if !strings.Contains(pattern, "{topic}") {
+		return "", fmt.Errorf("pattern %q does not contain {topic}", pattern)
+	}
+	values := map[string]string{
+		"{instance}":    instance,
+		"{environment}": environment,
+		"{topic}":       topic,
+	}
+	var unknown []string
+	name := topicPatternPlaceholder.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		value, ok := values[placeholder]
+		if !ok {
+			unknown = append(unknown, placeholder)
+		}
+		return value
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("pattern %q contains unknown placeholders %s", pattern, strings.Join(unknown, ", "))
+	}
+	return name, nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &applicationListResource{}
+
+func NewApplicationListResource(provider AxualProvider) list.ListResource {
+	return &applicationListResource{
+		provider: provider,
+	}
+}
+
+type applicationListResource struct {
+	provider AxualProvider
+}
+
+type applicationListResourceData struct {
+	NameRegex       types.String `tfsdk:"name_regex"`
+	Owners          types.String `tfsdk:"owners"`
+	ApplicationType types.String `tfsdk:"application_type"`
+}
+
+func (r *applicationListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+func (r *applicationListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the applications the user can see, to import them with `terraform query`. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list applications with a name matching this regular expression",
+			},
+			"owners": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list applications owned by the group with this Id",
+			},
+			"application_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list applications of this type. Possible values are Custom, Connector, or Ksml.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("Custom", "Connector", "Ksml"),
+				},
+			},
+		},
+	}
+}
+
+func (r *applicationListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data applicationListResourceData
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &data)...)
+	nameRegex := listRegex(data.NameRegex, "name_regex", &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	applications, err := r.provider.client.ListApplications(ctx)
+	if err != nil {
+		diags.AddError("LIST request error for application list resource", fmt.Sprintf("Error message: %s", err.Error()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var items []listItem
+	for _, application := range applications.Embedded.Applications {
+		if matchesRegex(nameRegex, application.Name) && matchesFilter(data.Owners, application.Owners.Uid) &&
+			matchesFilter(data.ApplicationType, application.ApplicationType) {
+			items = append(items, listItem{Id: application.Uid, DisplayName: application.Name})
+		}
+	}
+	stream.Results = listResults(ctx, req, NewApplicationResource(r.provider), items)
+}
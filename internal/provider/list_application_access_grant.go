@@ -0,0 +1,104 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &applicationAccessGrantListResource{}
+
+func NewApplicationAccessGrantListResource(provider AxualProvider) list.ListResource {
+	return &applicationAccessGrantListResource{
+		provider: provider,
+	}
+}
+
+type applicationAccessGrantListResource struct {
+	provider AxualProvider
+}
+
+type applicationAccessGrantListResourceData struct {
+	ApplicationId types.String `tfsdk:"application"`
+	TopicId       types.String `tfsdk:"topic"`
+	EnvironmentId types.String `tfsdk:"environment"`
+	AccessType    types.String `tfsdk:"access_type"`
+	Status        types.String `tfsdk:"status"`
+}
+
+func (r *applicationAccessGrantListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_access_grant"
+}
+
+func (r *applicationAccessGrantListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the application access grants the user can see, to import them with `terraform query`. The filters are applied by the API. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"application": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list grants of the application with this Id",
+			},
+			"topic": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list grants to the topic with this Id",
+			},
+			"environment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list grants in the environment with this Id",
+			},
+			"access_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list grants of this access type. Accepted values: CONSUMER, PRODUCER",
+				Validators: []validator.String{
+					stringvalidator.OneOf("CONSUMER", "PRODUCER"),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list grants with this status. Accepted values: Pending, Approved, Rejected, Revoked, Cancelled",
+				Validators: []validator.String{
+					stringvalidator.OneOf("Pending", "Approved", "Rejected", "Revoked", "Cancelled"),
+				},
+			},
+		},
+	}
+}
+
+func (r *applicationAccessGrantListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data applicationAccessGrantListResourceData
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &data)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	grants, err := r.provider.client.GetApplicationAccessGrantsByAttributes(ctx, webclient.ApplicationAccessGrantAttributes{
+		ApplicationId: data.ApplicationId.ValueString(),
+		TopicId:       data.TopicId.ValueString(),
+		EnvironmentId: data.EnvironmentId.ValueString(),
+		AccessType:    data.AccessType.ValueString(),
+		Statuses:      strings.ToUpper(data.Status.ValueString()),
+	})
+	if err != nil {
+		diags.AddError("LIST request error for application access grant list resource", fmt.Sprintf("Error message: %s", err.Error()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var items []listItem
+	for _, grant := range grants.Embedded.ApplicationAccessGrantResponses {
+		items = append(items, listItem{Id: grant.Uid, DisplayName: fmt.Sprintf("%s grant %s (%s)", strings.ToUpper(grant.AccessType), grant.Uid, grant.Status)})
+	}
+	stream.Results = listResults(ctx, req, NewApplicationAccessGrantResource(r.provider), items)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &groupListResource{}
+
+func NewGroupListResource(provider AxualProvider) list.ListResource {
+	return &groupListResource{
+		provider: provider,
+	}
+}
+
+type groupListResource struct {
+	provider AxualProvider
+}
+
+type groupListResourceData struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+}
+
+func (r *groupListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (r *groupListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the groups of the tenant, to import them with `terraform query`. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list groups with a name matching this regular expression",
+			},
+		},
+	}
+}
+
+func (r *groupListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data groupListResourceData
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &data)...)
+	nameRegex := listRegex(data.NameRegex, "name_regex", &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	groups, err := r.provider.client.ListGroups(ctx)
+	if err != nil {
+		diags.AddError("LIST request error for group list resource", fmt.Sprintf("Error message: %s", err.Error()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var items []listItem
+	for _, group := range groups.Embedded.Groups {
+		if matchesRegex(nameRegex, group.Name) {
+			items = append(items, listItem{Id: group.Uid, DisplayName: group.Name})
+		}
+	}
+	stream.Results = listResults(ctx, req, NewGroupResource(r.provider), items)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listItem is an object found by a list resource.
+type listItem struct {
+	Id          string
+	DisplayName string
+}
+
+// listResults streams the items as results of a list resource, up to the limit of the query. When Terraform asks
+// for the resources, e.g. to generate their configuration, each one is read with the Read of the managed resource,
+// as it would be after an import.
+func listResults(ctx context.Context, req list.ListRequest, r resource.Resource, items []listItem) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		for i, item := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			if !push(listResult(ctx, req, r, item)) {
+				return
+			}
+		}
+	}
+}
+
+func listResult(ctx context.Context, req list.ListRequest, r resource.Resource, item listItem) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = item.DisplayName
+	result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("id"), item.Id)...)
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	state := tfsdk.State{Schema: req.ResourceSchema, Raw: result.Resource.Raw}
+	result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("id"), item.Id)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+	readResp := &resource.ReadResponse{State: state, Identity: result.Identity}
+	r.Read(ctx, resource.ReadRequest{State: state, Identity: result.Identity}, readResp)
+	result.Diagnostics.Append(readResp.Diagnostics...)
+	result.Resource.Raw = readResp.State.Raw
+	return result
+}
+
+// listRegex compiles the optional regular expression of a list resource filter.
+func listRegex(expression types.String, attribute string, diags *diag.Diagnostics) *regexp.Regexp {
+	if expression.IsNull() || expression.IsUnknown() {
+		return nil
+	}
+	compiled, err := regexp.Compile(expression.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(attribute), "Invalid regular expression", fmt.Sprintf("Unable to compile %q: %s", expression.ValueString(), err))
+	}
+	return compiled
+}
+
+// matchesFilter reports whether the value matches an optional filter of a list resource.
+func matchesFilter(filter types.String, value string) bool {
+	return filter.IsNull() || filter.IsUnknown() || filter.ValueString() == value
+}
+
+// matchesRegex reports whether the value matches an optional regular expression of a list resource.
+func matchesRegex(expression *regexp.Regexp, value string) bool {
+	return expression == nil || expression.MatchString(value)
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type fakeTopicListAPI struct {
+	fakeAPI
+	topics []webclient.TopicResponse
+}
+
+func (f *fakeTopicListAPI) ListTopics(ctx context.Context) (*webclient.TopicsResponse, error) {
+	response := &webclient.TopicsResponse{}
+	response.Embedded.Topics = f.topics
+	return response, nil
+}
+
+func (f *fakeTopicListAPI) GetTopic(ctx context.Context, id string) (*webclient.TopicResponse, error) {
+	for _, topic := range f.topics {
+		if topic.Uid == id {
+			return &topic, nil
+		}
+	}
+	return nil, webclient.NotFoundError
+}
+
+func testTopic(uid string, name string, owners string) webclient.TopicResponse {
+	topic := webclient.TopicResponse{Uid: uid, Name: name, KeyType: "String", ValueType: "String", RetentionPolicy: "delete"}
+	topic.Embedded.Owners.Uid = owners
+	return topic
+}
+
+func TestTopicListResourceList(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		config          map[string]tftypes.Value
+		limit           int64
+		includeResource bool
+		wantNames       []string
+		wantError       bool
+	}{
+		{
+			desc:      "every topic is listed without filters",
+			wantNames: []string{"orders", "orders-dlq", "payments"},
+		},
+		{
+			desc:      "topics are filtered by name and owners",
+			config:    map[string]tftypes.Value{"name_regex": tftypes.NewValue(tftypes.String, "^orders"), "owners": tftypes.NewValue(tftypes.String, "team-a")},
+			wantNames: []string{"orders"},
+		},
+		{
+			desc:      "the limit of the query is respected",
+			limit:     2,
+			wantNames: []string{"orders", "orders-dlq"},
+		},
+		{
+			desc:            "the resources are read when they are included",
+			limit:           1,
+			includeResource: true,
+			wantNames:       []string{"orders"},
+		},
+		{
+			desc:      "an invalid regular expression fails",
+			config:    map[string]tftypes.Value{"name_regex": tftypes.NewValue(tftypes.String, "(")},
+			wantError: true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			api := &fakeTopicListAPI{topics: []webclient.TopicResponse{
+				testTopic("uid-1", "orders", "team-a"),
+				testTopic("uid-2", "orders-dlq", "team-b"),
+				testTopic("uid-3", "payments", "team-a"),
+			}}
+			provider := testProvider(api)
+			r := NewTopicListResource(provider)
+			schemaResp := &list.ListResourceSchemaResponse{}
+			r.ListResourceConfigSchema(context.Background(), list.ListResourceSchemaRequest{}, schemaResp)
+			s := schemaResp.Schema
+			req := list.ListRequest{
+				Config:                 tfsdk.Config{Schema: s, Raw: objectValue(t, s, c.config)},
+				IncludeResource:        c.includeResource,
+				Limit:                  c.limit,
+				ResourceSchema:         resourceSchema(t, NewTopicResource(provider)),
+				ResourceIdentitySchema: idIdentitySchema(),
+			}
+			stream := &list.ListResultsStream{}
+			r.List(context.Background(), req, stream)
+
+			var names []string
+			for result := range stream.Results {
+				if result.Diagnostics.HasError() {
+					if !c.wantError {
+						t.Fatalf("unexpected errors: %v", result.Diagnostics)
+					}
+					return
+				}
+				names = append(names, result.DisplayName)
+				var id types.String
+				result.Diagnostics.Append(result.Identity.GetAttribute(context.Background(), path.Root("id"), &id)...)
+				if id.IsNull() {
+					t.Errorf("expected an identity for %s", result.DisplayName)
+				}
+				if c.includeResource {
+					var name types.String
+					result.Diagnostics.Append(result.Resource.GetAttribute(context.Background(), path.Root("name"), &name)...)
+					if name.ValueString() != result.DisplayName {
+						t.Errorf("expected the resource of %s, got %s", result.DisplayName, name)
+					}
+				}
+			}
+			if c.wantError {
+				t.Fatal("expected an error")
+			}
+			if !reflect.DeepEqual(names, c.wantNames) {
+				t.Errorf("expected topics %v, got %v", c.wantNames, names)
+			}
+		})
+	}
+}
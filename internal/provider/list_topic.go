@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &topicListResource{}
+
+func NewTopicListResource(provider AxualProvider) list.ListResource {
+	return &topicListResource{
+		provider: provider,
+	}
+}
+
+type topicListResource struct {
+	provider AxualProvider
+}
+
+type topicListResourceData struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Owners    types.String `tfsdk:"owners"`
+}
+
+func (r *topicListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_topic"
+}
+
+func (r *topicListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the topics the user can see, to import them with `terraform query`. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list topics with a name matching this regular expression",
+			},
+			"owners": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list topics owned by the group with this Id",
+			},
+		},
+	}
+}
+
+func (r *topicListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data topicListResourceData
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &data)...)
+	nameRegex := listRegex(data.NameRegex, "name_regex", &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	topics, err := r.provider.client.ListTopics(ctx)
+	if err != nil {
+		diags.AddError("LIST request error for topic list resource", fmt.Sprintf("Error message: %s", err.Error()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var items []listItem
+	for _, topic := range topics.Embedded.Topics {
+		if matchesRegex(nameRegex, topic.Name) && matchesFilter(data.Owners, topic.Embedded.Owners.Uid) {
+			items = append(items, listItem{Id: topic.Uid, DisplayName: topic.Name})
+		}
+	}
+	stream.Results = listResults(ctx, req, NewTopicResource(r.provider), items)
+}
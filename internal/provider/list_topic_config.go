@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &topicConfigListResource{}
+
+func NewTopicConfigListResource(provider AxualProvider) list.ListResource {
+	return &topicConfigListResource{
+		provider: provider,
+	}
+}
+
+type topicConfigListResource struct {
+	provider AxualProvider
+}
+
+type topicConfigListResourceData struct {
+	TopicId       types.String `tfsdk:"topic"`
+	EnvironmentId types.String `tfsdk:"environment"`
+}
+
+func (r *topicConfigListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_topic_config"
+}
+
+func (r *topicConfigListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the topic configs the user can see, to import them with `terraform query`. Requires Terraform 1.14 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"topic": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list configs of the topic with this Id",
+			},
+			"environment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list configs in the environment with this Id",
+			},
+		},
+	}
+}
+
+func (r *topicConfigListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data topicConfigListResourceData
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &data)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	configs, err := r.provider.client.ListTopicConfigs(ctx)
+	if err != nil {
+		diags.AddError("LIST request error for topic config list resource", fmt.Sprintf("Error message: %s", err.Error()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var items []listItem
+	for _, config := range configs.Embedded.TopicConfigs {
+		topic, environment := config.Embedded.Stream, config.Embedded.Environment
+		if matchesFilter(data.TopicId, topic.Uid) && matchesFilter(data.EnvironmentId, environment.Uid) {
+			items = append(items, listItem{Id: config.Uid, DisplayName: fmt.Sprintf("%s in %s", topic.Name, environment.ShortName)})
+		}
+	}
+	stream.Results = listResults(ctx, req, NewTopicConfigResource(r.provider), items)
+}
@@ -3,25 +3,45 @@ package provider
 import (
 	webclient "axual-webclient"
 	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
+	custom_validator "axual.com/terraform-provider-axual/internal/custom-validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &AxualProvider{}
 var _ provider.ProviderWithFunctions = &AxualProvider{}
+var _ provider.ProviderWithEphemeralResources = &AxualProvider{}
+var _ provider.ProviderWithActions = &AxualProvider{}
+var _ provider.ProviderWithListResources = &AxualProvider{}
 
 type AxualProvider struct {
 	// client can contain the upstream provider SDK or HTTP client used to
 	// communicate with the upstream service. Resource and DataSource
 	// implementations can then make calls using this client.
-	client *webclient.Client
+	client webclient.AxualAPI
+
+	// injected is set when the client was given to NewWithClient, so Configure keeps it
+	// instead of connecting to the platform in the provider block.
+	injected bool
 
 	// configured is set to true at the end of the Configure method.
 	// This can be used in Resource and DataSource implementations to verify
@@ -45,6 +65,40 @@ type providerData struct {
 	Scopes   types.List   `tfsdk:"scopes"`
 	Audience types.String `tfsdk:"audience"`
 	AuthMode types.String `tfsdk:"authmode"`
+	Issuer   types.String `tfsdk:"issuer"`
+
+	GrantType              types.String `tfsdk:"grant_type"`
+	ClientSecret           types.String `tfsdk:"client_secret"`
+	ClientAssertionKeyFile types.String `tfsdk:"client_assertion_key_file"`
+	ClientAssertionKeyPEM  types.String `tfsdk:"client_assertion_key_pem"`
+	ClientAssertionKeyID   types.String `tfsdk:"client_assertion_key_id"`
+	SubjectTokenFile       types.String `tfsdk:"subject_token_file"`
+	SubjectTokenEnvVar     types.String `tfsdk:"subject_token_env_var"`
+	SubjectTokenType       types.String `tfsdk:"subject_token_type"`
+	AccessToken            types.String `tfsdk:"access_token"`
+	AccessTokenFile        types.String `tfsdk:"access_token_file"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	PollInterval types.String `tfsdk:"poll_interval"`
+	PollTimeout  types.String `tfsdk:"poll_timeout"`
+
+	RequestTimeout        types.String  `tfsdk:"request_timeout"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	ProxyURL types.String `tfsdk:"proxy_url"`
+	NoProxy  types.List   `tfsdk:"no_proxy"`
+	Headers  types.Map    `tfsdk:"headers"`
 }
 
 func (p *AxualProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -55,44 +109,58 @@ func (p *AxualProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if p.injected {
+		p.configured = true
+		return
+	}
 
 	apiurl := data.ApiUrl.ValueString()
 	realm := data.Realm.ValueString()
 
-	var username string
-	if data.Username.IsNull() {
-		username = os.Getenv("AXUAL_AUTH_USERNAME")
-		if username == "" {
-			resp.Diagnostics.AddError(
-				"Missing Username",
-				"Username is not provided in configuration and the AXUAL_AUTH_USERNAME environment variable is not set.",
-			)
-			return
-		}
-	} else {
-		username = data.Username.ValueString()
+	grantType := stringFromConfigOrEnv(data.GrantType, "AXUAL_AUTH_GRANT_TYPE")
+	if grantType == "" {
+		grantType = "password"
 	}
 
-	var password string
-	if data.Password.IsUnknown() {
-		// Cannot connect to client with an unknown value
-		resp.Diagnostics.AddError(
-			"Unable to create client",
-			"Cannot use unknown value as host",
-		)
-		return
-	}
-	if data.Password.IsNull() {
-		password = os.Getenv("AXUAL_AUTH_PASSWORD")
-		if password == "" {
+	accessToken := stringFromConfigOrEnv(data.AccessToken, "AXUAL_AUTH_ACCESS_TOKEN")
+	accessTokenFile := stringFromConfigOrEnv(data.AccessTokenFile, "AXUAL_AUTH_ACCESS_TOKEN_FILE")
+
+	// Username and password are only needed when signing in as a user.
+	var username, password string
+	if grantType == "password" && accessToken == "" && accessTokenFile == "" {
+		if data.Username.IsNull() {
+			username = os.Getenv("AXUAL_AUTH_USERNAME")
+			if username == "" {
+				resp.Diagnostics.AddError(
+					"Missing Username",
+					"Username is not provided in configuration and the AXUAL_AUTH_USERNAME environment variable is not set.",
+				)
+				return
+			}
+		} else {
+			username = data.Username.ValueString()
+		}
+
+		if data.Password.IsUnknown() {
+			// Cannot connect to client with an unknown value
 			resp.Diagnostics.AddError(
-				"Missing Password",
-				"Password is not provided in configuration and the AXUAL_AUTH_PASSWORD environment variable is not set.",
+				"Unable to create client",
+				"Cannot use unknown value as host",
 			)
 			return
 		}
-	} else {
-		password = data.Password.ValueString()
+		if data.Password.IsNull() {
+			password = os.Getenv("AXUAL_AUTH_PASSWORD")
+			if password == "" {
+				resp.Diagnostics.AddError(
+					"Missing Password",
+					"Password is not provided in configuration and the AXUAL_AUTH_PASSWORD environment variable is not set.",
+				)
+				return
+			}
+		} else {
+			password = data.Password.ValueString()
+		}
 	}
 
 	auth := webclient.AuthStruct{
@@ -102,9 +170,42 @@ func (p *AxualProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		ClientId: data.ClientID.ValueString(),
 		Audience: data.Audience.ValueString(),
 		AuthMode: data.AuthMode.ValueString(),
+		Issuer:   data.Issuer.ValueString(),
+
+		GrantType:      grantType,
+		ClientSecret:   stringFromConfigOrEnv(data.ClientSecret, "AXUAL_AUTH_CLIENT_SECRET"),
+		PrivateKeyPEM:  stringFromConfigOrEnv(data.ClientAssertionKeyPEM, "AXUAL_AUTH_CLIENT_ASSERTION_KEY"),
+		PrivateKeyFile: data.ClientAssertionKeyFile.ValueString(),
+		PrivateKeyID:   data.ClientAssertionKeyID.ValueString(),
+
+		SubjectTokenFile: data.SubjectTokenFile.ValueString(),
+		SubjectTokenEnv:  data.SubjectTokenEnvVar.ValueString(),
+		SubjectTokenType: data.SubjectTokenType.ValueString(),
+
+		AccessToken:     accessToken,
+		AccessTokenFile: accessTokenFile,
 	}
-	// Default to keycloak if authmode is not set.
-	if auth.AuthMode == "" {
+	if auth.SubjectTokenFile == "" && auth.SubjectTokenEnv == "" {
+		auth.SubjectTokenEnv = "AXUAL_AUTH_SUBJECT_TOKEN"
+	}
+	if grantType == "client_credentials" && accessToken == "" && accessTokenFile == "" && auth.ClientSecret == "" && auth.PrivateKeyPEM == "" && auth.PrivateKeyFile == "" {
+		resp.Diagnostics.AddError(
+			"Missing Client Credentials",
+			"The client_credentials grant requires client_secret (or the AXUAL_AUTH_CLIENT_SECRET environment variable), "+
+				"or a private key for private_key_jwt client authentication in client_assertion_key_file or client_assertion_key_pem "+
+				"(or the AXUAL_AUTH_CLIENT_ASSERTION_KEY environment variable).",
+		)
+		return
+	}
+	if auth.Url == "" && auth.Issuer == "" && accessToken == "" && accessTokenFile == "" {
+		resp.Diagnostics.AddError(
+			"Missing Token Endpoint",
+			"Either authurl or issuer must be configured to acquire a token.",
+		)
+		return
+	}
+	// Default to keycloak if authmode is not set. With an issuer, the auth mode is derived from the issuer.
+	if auth.AuthMode == "" && auth.Issuer == "" {
 		auth.AuthMode = "keycloak"
 	}
 
@@ -116,7 +217,61 @@ func (p *AxualProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		auth.Scopes = scopes
 	}
 
-	c, err := webclient.NewClient(apiurl, realm, auth)
+	// Unknown values, e.g. from resources that are not created yet, keep the defaults like null values.
+	// The formats are checked by the schema validators.
+	retryPolicy := webclient.DefaultRetryPolicy()
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		retryPolicy.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	retryPolicy.MaxWait = durationFromConfig(data.RetryMaxWait, retryPolicy.MaxWait)
+	retryPolicy.MinWait = min(retryPolicy.MinWait, retryPolicy.MaxWait)
+
+	pollingPolicy := webclient.DefaultPollingPolicy()
+	pollingPolicy.Interval = durationFromConfig(data.PollInterval, pollingPolicy.Interval)
+	pollingPolicy.Timeout = durationFromConfig(data.PollTimeout, pollingPolicy.Timeout)
+	requestTimeout := durationFromConfig(data.RequestTimeout, webclient.DefaultRequestTimeout)
+	rateLimit := webclient.RateLimit{
+		RequestsPerSecond:     data.RequestsPerSecond.ValueFloat64(),
+		MaxConcurrentRequests: int(data.MaxConcurrentRequests.ValueInt64()),
+	}
+
+	tlsConfig := webclient.TLSConfig{
+		CACertFile:         data.CACertFile.ValueString(),
+		CACertPEM:          data.CACertPEM.ValueString(),
+		ClientCertFile:     data.ClientCertFile.ValueString(),
+		ClientKeyFile:      data.ClientKeyFile.ValueString(),
+		ClientCertPEM:      data.ClientCertPEM.ValueString(),
+		ClientKeyPEM:       data.ClientKeyPEM.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	proxyConfig := webclient.ProxyConfig{URL: data.ProxyURL.ValueString()}
+	resp.Diagnostics.Append(data.NoProxy.ElementsAs(ctx, &proxyConfig.NoProxy, false)...)
+	var headers map[string]string
+	resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, err := webclient.NewClient(ctx, apiurl, realm, auth,
+		webclient.WithRetryPolicy(retryPolicy),
+		webclient.WithPollingPolicy(pollingPolicy),
+		webclient.WithRequestTimeout(requestTimeout),
+		webclient.WithRateLimit(rateLimit),
+		webclient.WithTLSConfig(tlsConfig),
+		webclient.WithProxy(proxyConfig),
+		webclient.WithHeaders(headers),
+	)
+	var discoveryErr *webclient.DiscoveryError
+	if errors.As(err, &discoveryErr) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("issuer"),
+			"Invalid Issuer",
+			"Unable to use the OpenID Connect discovery document of the issuer. Check that the issuer URL is reachable "+
+				"and that the authorization server supports the configured grant type and client authentication:\n\n"+err.Error(),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create client",
@@ -125,7 +280,36 @@ func (p *AxualProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	// Resources check the features of the platform before using them; without a version, all features are assumed.
+	if _, err := c.DetectPlatform(ctx); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Detect the Platform Version",
+			"The provider could not read the build info of the Platform Manager and assumes the latest platform:\n\n"+err.Error(),
+		)
+	}
+
 	p.client = c
+	p.configured = true
+}
+
+// stringFromConfigOrEnv returns the configured value, or the value of the environment variable when it is not configured.
+func stringFromConfigOrEnv(value types.String, env string) string {
+	if value.IsNull() {
+		return os.Getenv(env)
+	}
+	return value.ValueString()
+}
+
+// durationFromConfig returns the configured duration, or fallback when the value is null or unknown.
+func durationFromConfig(value types.String, fallback time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return fallback
+	}
+	return duration
 }
 
 func (p *AxualProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -153,12 +337,41 @@ func (p *AxualProvider) Metadata(ctx context.Context, req provider.MetadataReque
 }
 
 func (p *AxualProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewPrincipalFromPemFunction,
+		NewTopicFullNameFunction,
+		NewParseImportIdFunction,
+	}
+}
+
+func (p *AxualProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource { return NewApplicationCredentialEphemeralResource(*p) },
+	}
+}
+
+func (p *AxualProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		func() action.Action { return NewDeploymentStartAction(*p) },
+		func() action.Action { return NewDeploymentStopAction(*p) },
+		func() action.Action { return NewDeploymentRestartAction(*p) },
+	}
+}
+
+func (p *AxualProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		func() list.ListResource { return NewTopicListResource(*p) },
+		func() list.ListResource { return NewApplicationListResource(*p) },
+		func() list.ListResource { return NewTopicConfigListResource(*p) },
+		func() list.ListResource { return NewApplicationAccessGrantListResource(*p) },
+		func() list.ListResource { return NewGroupListResource(*p) },
+	}
 }
 
 func (p *AxualProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		func() datasource.DataSource { return NewApplicationDataSource(*p) },
+		func() datasource.DataSource { return NewPlatformDataSource(*p) },
 		func() datasource.DataSource { return NewGroupDataSource(*p) },
 		func() datasource.DataSource { return NewTopicDataSource(*p) },
 		func() datasource.DataSource { return NewEnvironmentDataSource(*p) },
@@ -181,7 +394,7 @@ func (p *AxualProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Username for all requests. Will be used to acquire a token",
+				MarkdownDescription: "Username for all requests. Will be used to acquire a token with the `password` grant",
 				Optional:            true,
 			},
 			"password": schema.StringAttribute{
@@ -194,8 +407,12 @@ func (p *AxualProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Required:            true,
 			},
 			"authurl": schema.StringAttribute{
-				MarkdownDescription: "Token URL",
-				Required:            true,
+				MarkdownDescription: "Token URL. Can be omitted when `issuer` is configured",
+				Optional:            true,
+			},
+			"issuer": schema.StringAttribute{
+				MarkdownDescription: "OpenID Connect issuer URL, e.g. `https://keycloak.example.com/auth/realms/axual`. The token URL and auth mode are taken from its `.well-known/openid-configuration` document unless `authurl` or `authmode` are configured, and the supported grant types and client authentication methods are checked when the provider is configured. With `access_token` or `access_token_file`, the document is not read and the auth mode is taken from the issuer URL",
+				Optional:            true,
 			},
 			"scopes": schema.ListAttribute{
 				MarkdownDescription: "OAuth authorization server scopes",
@@ -207,8 +424,171 @@ func (p *AxualProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 			},
 			"authmode": schema.StringAttribute{
-				MarkdownDescription: "Authentication mode to use: keycloak or auth0 (defaults to keycloak)",
+				MarkdownDescription: "Authentication mode to use: keycloak or auth0 (defaults to keycloak, or to the kind of `issuer` when configured)",
+				Optional:            true,
+			},
+			"grant_type": schema.StringAttribute{
+				MarkdownDescription: "OAuth grant used to acquire a token: `password` to sign in as the user in `username`, `client_credentials` to sign in as the client itself, e.g. a CI service account, or `token_exchange` (RFC 8693) and `jwt_bearer` (RFC 7523) to exchange a JWT of another identity provider, e.g. the OIDC token of a CI runner. Can also be set with the AXUAL_AUTH_GRANT_TYPE environment variable (defaults to password)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("password", "client_credentials", "token_exchange", "jwt_bearer"),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret for the `client_credentials` grant. It can be omitted if the environment variable AXUAL_AUTH_CLIENT_SECRET is used",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_assertion_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file with an RSA or ECDSA private key. When set, the client authenticates to the token endpoint with a signed JWT (`private_key_jwt`) instead of `client_secret`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_assertion_key_pem")),
+				},
+			},
+			"client_assertion_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded RSA or ECDSA private key for `private_key_jwt` client authentication. It can be omitted if the environment variable AXUAL_AUTH_CLIENT_ASSERTION_KEY is used",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_assertion_key_id": schema.StringAttribute{
+				MarkdownDescription: "Key ID sent in the `kid` header of the `private_key_jwt` client assertion, required when the authorization server has more than one key registered for the client",
+				Optional:            true,
+			},
+			"subject_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with the JWT exchanged with the `token_exchange` and `jwt_bearer` grants. The file is read again for every token request, so tokens rotated by the CI runner are picked up",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("subject_token_env_var")),
+				},
+			},
+			"subject_token_env_var": schema.StringAttribute{
+				MarkdownDescription: "Name of the environment variable with the JWT exchanged with the `token_exchange` and `jwt_bearer` grants (defaults to AXUAL_AUTH_SUBJECT_TOKEN)",
+				Optional:            true,
+			},
+			"subject_token_type": schema.StringAttribute{
+				MarkdownDescription: "Token type of the subject token for the `token_exchange` grant (defaults to `urn:ietf:params:oauth:token-type:jwt`)",
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Static bearer token used for all requests instead of signing in. It can be omitted if the environment variable AXUAL_AUTH_ACCESS_TOKEN is used",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("access_token_file")),
+				},
+			},
+			"access_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with a bearer token used for all requests instead of signing in. The file is read again when the token expires, so tokens rotated by another process are picked up. It can be omitted if the environment variable AXUAL_AUTH_ACCESS_TOKEN_FILE is used",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of times a request is retried after a transient failure. `429` and `503` responses are retried for every request. Network errors and `409`, `500`, `502` and `504` responses are only retried for requests that are safe to repeat (`GET`, `PUT` and `DELETE`); creating or updating a topic config and activating a principal are also retried on `409`. Set to `0` to disable retries (defaults to 4)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait between two retries, as a duration such as `30s` or `2m`. The wait grows exponentially up to this value; a `Retry-After` header sent by the API is honoured up to this value as well (defaults to `30s`)",
+				Optional:            true,
+				Validators: []validator.String{
+					custom_validator.NewDurationValidator(),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Time between two checks whether a change is applied by the platform, such as an approved grant or a new topic config, as a duration such as `1s` (defaults to `1s`)",
+				Optional:            true,
+				Validators: []validator.String{
+					custom_validator.NewDurationValidator(),
+				},
+			},
+			"poll_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait until a change is applied by the platform, as a duration such as `10m` (defaults to `5m`). The wait also ends with the `timeouts` of the resource",
+				Optional:            true,
+				Validators: []validator.String{
+					custom_validator.NewDurationValidator(),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time of a single request to the API or the token endpoint, as a duration such as `1m` (defaults to `30s`). Retries and waits are bounded by the `timeouts` of the resource instead",
 				Optional:            true,
+				Validators: []validator.String{
+					custom_validator.NewDurationValidator(),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate of requests to the API, such as `5` or `0.5`. Requests above the rate wait for their turn, which avoids throttling by the API gateway when Terraform runs many operations in parallel (unlimited by default)",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests to the API in flight at the same time, independent of the `-parallelism` of Terraform (unlimited by default)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file with CA certificates to trust for the API and the token endpoint, in addition to the system certificates",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust for the API and the token endpoint, in addition to the system certificates",
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file with the client certificate used for mutual TLS with the API and the token endpoint. Requires `client_key_file` or `client_key_pem`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file with the private key of the client certificate",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate used for mutual TLS with the API and the token endpoint. Requires `client_key_file` or `client_key_pem`",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the server certificates of the API and the token endpoint. Only use this for local test platforms (defaults to false)",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP proxy for the requests to the API and the token endpoint, e.g. `http://proxy.example.com:3128`. Hosts in `no_proxy` are reached directly. Without it, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^(https?|socks5)://`), "must be a URL starting with http://, https:// or socks5://"),
+				},
+			},
+			"no_proxy": schema.ListAttribute{
+				MarkdownDescription: "Hosts that are reached without the `proxy_url`, such as the token endpoint, with the semantics of the `NO_PROXY` environment variable: a host name matches its subdomains, and IP addresses, CIDR ranges and ports are supported. Defaults to the `NO_PROXY` environment variable",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.AlsoRequires(path.MatchRoot("proxy_url")),
+				},
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Headers added to every request to the API and the token endpoint, e.g. a tenant header required by an API gateway. Headers set by the provider, such as `Authorization`, are not replaced",
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
 			},
 		},
 	}
@@ -221,3 +601,15 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// NewWithClient returns a provider that uses the given API instead of connecting to the platform
+// configured in the provider block, e.g. a fake in unit tests of the resources.
+func NewWithClient(version string, client webclient.AxualAPI) func() provider.Provider {
+	return func() provider.Provider {
+		return &AxualProvider{
+			version:  version,
+			client:   client,
+			injected: true,
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationFromConfig(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		value types.String
+		want  time.Duration
+	}{
+		{
+			desc:  "a configured duration is parsed",
+			value: types.StringValue("2m"),
+			want:  2 * time.Minute,
+		},
+		{
+			desc:  "a zero duration is kept",
+			value: types.StringValue("0s"),
+			want:  0,
+		},
+		{
+			desc:  "null keeps the default",
+			value: types.StringNull(),
+			want:  5 * time.Minute,
+		},
+		{
+			desc:  "unknown keeps the default",
+			value: types.StringUnknown(),
+			want:  5 * time.Minute,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := durationFromConfig(c.value, 5*time.Minute); got != c.want {
+				t.Fatalf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
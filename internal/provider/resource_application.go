@@ -8,6 +8,7 @@ import (
 	"regexp"
 
 	custom_validator "axual.com/terraform-provider-axual/internal/custom-validator"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -22,6 +23,7 @@ import (
 
 var _ resource.Resource = &applicationResource{}
 var _ resource.ResourceWithImportState = &applicationResource{}
+var _ resource.ResourceWithIdentity = &applicationResource{}
 
 func NewApplicationResource(provider AxualProvider) resource.Resource {
 	return &applicationResource{
@@ -33,24 +35,32 @@ type applicationResource struct {
 	provider AxualProvider
 }
 
+// applicationAPIFields are the attributes the API can report validation errors for.
+var applicationAPIFields = []string{"name", "short_name", "description", "application_type", "application_class", "application_id", "type", "owners", "viewers", "visibility"}
+
 type ApplicationResourceData struct {
-	Name             types.String `tfsdk:"name"`
-	ShortName        types.String `tfsdk:"short_name"`
-	Description      types.String `tfsdk:"description"`
-	ApplicationType  types.String `tfsdk:"application_type"`
-	ApplicationClass types.String `tfsdk:"application_class"`
-	ApplicationId    types.String `tfsdk:"application_id"`
-	Type             types.String `tfsdk:"type"`
-	Owners           types.String `tfsdk:"owners"`
-	Viewers          types.Set    `tfsdk:"viewers"`
-	Visibility       types.String `tfsdk:"visibility"`
-	Id               types.String `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	ShortName        types.String   `tfsdk:"short_name"`
+	Description      types.String   `tfsdk:"description"`
+	ApplicationType  types.String   `tfsdk:"application_type"`
+	ApplicationClass types.String   `tfsdk:"application_class"`
+	ApplicationId    types.String   `tfsdk:"application_id"`
+	Type             types.String   `tfsdk:"type"`
+	Owners           types.String   `tfsdk:"owners"`
+	Viewers          types.Set      `tfsdk:"viewers"`
+	Visibility       types.String   `tfsdk:"visibility"`
+	Id               types.String   `tfsdk:"id"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *applicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_application"
 }
 
+func (r *applicationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
 func (r *applicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
@@ -129,6 +139,9 @@ func (r *applicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -142,14 +155,17 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	ApplicationRequest, err := createApplicationRequestFromData(ctx, &data, *r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating CREATE request struct for application resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
-	Application, err := r.provider.client.CreateApplication(ApplicationRequest)
+	Application, err := r.provider.client.CreateApplication(ctx, ApplicationRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("CREATE request error for application resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "CREATE request error for application resource", fmt.Sprintf("Error message: %s", err.Error()), err, applicationAPIFields...)
 		return
 	}
 
@@ -158,6 +174,7 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -170,7 +187,7 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	Application, err := r.provider.client.GetApplication(data.Id.ValueString())
+	Application, err := r.provider.client.GetApplication(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Application not found. Id: %s", data.Id.ValueString()))
@@ -187,6 +204,7 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 	tflog.Info(ctx, "During READ, saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -194,20 +212,22 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
-	tflog.Error(ctx, fmt.Sprintf("Application  %q", data))
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	ApplicationRequest, err := createApplicationRequestFromData(ctx, &data, *r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating UPDATE request struct for application resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
-	Application, err := r.provider.client.UpdateApplication(data.Id.ValueString(), ApplicationRequest)
+	Application, err := r.provider.client.UpdateApplication(ctx, data.Id.ValueString(), ApplicationRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update Application, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Client Error", fmt.Sprintf("Unable to update Application, got error: %s", err), err, applicationAPIFields...)
 		return
 	}
 
@@ -215,6 +235,7 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -227,7 +248,10 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.provider.client.DeleteApplication(data.Id.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteApplication(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Application, got error: %s", err))
 		return
@@ -235,7 +259,7 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func createApplicationRequestFromData(ctx context.Context, data *ApplicationResourceData, r applicationResource) (webclient.ApplicationRequest, error) {
@@ -249,7 +273,7 @@ func createApplicationRequestFromData(ctx context.Context, data *ApplicationReso
 	if err != nil {
 		return webclient.ApplicationRequest{}, err
 	}
-	owners = fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, owners)
+	owners = fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), owners)
 
 	viewers := []string{}
 	if !data.Viewers.IsNull() {
@@ -260,7 +284,7 @@ func createApplicationRequestFromData(ctx context.Context, data *ApplicationReso
 		}
 
 		for _, viewer := range viewerUIDs {
-			fullURL := fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, viewer)
+			fullURL := fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), viewer)
 			viewers = append(viewers, fullURL)
 		}
 	}
@@ -6,8 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +21,7 @@ import (
 
 var _ resource.Resource = &applicationAccessGrantResource{}
 var _ resource.ResourceWithImportState = &applicationAccessGrantResource{}
+var _ resource.ResourceWithIdentity = &applicationAccessGrantResource{}
 
 func NewApplicationAccessGrantResource(provider AxualProvider) resource.Resource {
 	return &applicationAccessGrantResource{
@@ -32,18 +33,23 @@ type applicationAccessGrantResource struct {
 	provider AxualProvider
 }
 type applicationAccessGrantData struct {
-	Id            types.String `tfsdk:"id"`
-	ApplicationId types.String `tfsdk:"application"`
-	TopicId       types.String `tfsdk:"topic"`
-	EnvironmentId types.String `tfsdk:"environment"`
-	Status        types.String `tfsdk:"status"`
-	AccessType    types.String `tfsdk:"access_type"`
+	Id            types.String   `tfsdk:"id"`
+	ApplicationId types.String   `tfsdk:"application"`
+	TopicId       types.String   `tfsdk:"topic"`
+	EnvironmentId types.String   `tfsdk:"environment"`
+	Status        types.String   `tfsdk:"status"`
+	AccessType    types.String   `tfsdk:"access_type"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *applicationAccessGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_application_access_grant"
 }
 
+func (r *applicationAccessGrantResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
 func (r *applicationAccessGrantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Application Access Grant resource. Purpose of a grant is to request access to a topic in an environment. Read more: https://docs.axual.io/axual/2026.1/self-service/application-management.html#requesting-topic-access",
@@ -79,6 +85,9 @@ func (r *applicationAccessGrantResource) Schema(ctx context.Context, req resourc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": createDeleteTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -92,6 +101,9 @@ func (r *applicationAccessGrantResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	applicationAccessGrantRequestData := webclient.ApplicationAccessGrantRequest{
 		EnvironmentId: data.EnvironmentId.ValueString(),
 		StreamId:      data.TopicId.ValueString(),
@@ -99,7 +111,7 @@ func (r *applicationAccessGrantResource) Create(ctx context.Context, req resourc
 		AccessType:    data.AccessType.ValueString(),
 	}
 
-	ApplicationAccessGrant, err := r.provider.client.CreateApplicationAccessGrant(applicationAccessGrantRequestData)
+	ApplicationAccessGrant, err := r.provider.client.CreateApplicationAccessGrant(ctx, applicationAccessGrantRequestData)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating Application Access Grant", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -114,6 +126,7 @@ func (r *applicationAccessGrantResource) Create(ctx context.Context, req resourc
 	tflog.Info(ctx, "Saving Application Access Grant resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *applicationAccessGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -127,7 +140,7 @@ func (r *applicationAccessGrantResource) Read(ctx context.Context, req resource.
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Reading Application Access Grant. Id: %s", data.Id.ValueString()))
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.Id.ValueString())
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Application Access Grant not found. Id: %s", data.Id.ValueString()))
@@ -153,9 +166,13 @@ func (r *applicationAccessGrantResource) Read(ctx context.Context, req resource.
 	tflog.Info(ctx, "Saving Application Access Grant resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *applicationAccessGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if updateTimeouts(ctx, req, resp) {
+		return
+	}
 	resp.Diagnostics.AddError(
 		"Application Access Grant cannot be updated",
 		`The Axual API does not support updating grant attributes. To change access_type, application, topic, or environment:
@@ -178,7 +195,10 @@ func (r *applicationAccessGrantResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.Id.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.Id.ValueString())
 	if err != nil {
 		// If grant not found, it's already deleted - success
 		if errors.Is(err, webclient.NotFoundError) {
@@ -203,12 +223,9 @@ func (r *applicationAccessGrantResource) Delete(ctx context.Context, req resourc
 	// Pending grants - can be cancelled
 	if applicationAccessGrant.Links.Cancel.Href != "" {
 		tflog.Info(ctx, fmt.Sprintf("Cancelling pending grant. Id: %s", data.Id.ValueString()))
-		// Retry logic for cancelling the grant to give time for Kafka to propagate changes
-		err1 := Retry(3, 3*time.Second, func() error {
-			return r.provider.client.CancelGrant(data.Id.ValueString())
-		})
+		err1 := r.provider.client.CancelGrant(ctx, data.Id.ValueString())
 		if err1 != nil {
-			resp.Diagnostics.AddError("Unable to cancel Application Access Grant", fmt.Sprintf("Error message after retries: %s", err1))
+			resp.Diagnostics.AddError("Unable to cancel Application Access Grant", fmt.Sprintf("Error message: %s", err1))
 			return
 		}
 		return
@@ -219,7 +236,7 @@ func (r *applicationAccessGrantResource) Delete(ctx context.Context, req resourc
 	// between grant and approval resources is lost and they're destroyed in parallel
 	if applicationAccessGrant.Status == "Approved" && applicationAccessGrant.Links.Revoke.Href != "" {
 		tflog.Info(ctx, fmt.Sprintf("Revoking approved grant before deletion. Id: %s", data.Id.ValueString()))
-		err := r.provider.client.RevokeOrDenyGrant(data.Id.ValueString(), "Revoked during terraform destroy")
+		err := r.provider.client.RevokeOrDenyGrant(ctx, data.Id.ValueString(), "Revoked during terraform destroy")
 		if err != nil {
 			// Handle race condition: approval resource may have been revoked at the same time
 			// The API is not idempotent - revoking an already-revoked grant throws an error
@@ -227,7 +244,7 @@ func (r *applicationAccessGrantResource) Delete(ctx context.Context, req resourc
 			tflog.Warn(ctx, fmt.Sprintf("Revoke failed, checking if grant was revoked by another process. Id: %s, Error: %s",
 				data.Id.ValueString(), err.Error()))
 
-			updatedGrant, fetchErr := r.provider.client.GetApplicationAccessGrant(data.Id.ValueString())
+			updatedGrant, fetchErr := r.provider.client.GetApplicationAccessGrant(ctx, data.Id.ValueString())
 			if fetchErr != nil {
 				if errors.Is(fetchErr, webclient.NotFoundError) {
 					tflog.Info(ctx, fmt.Sprintf("Grant was deleted by another process. Id: %s", data.Id.ValueString()))
@@ -263,5 +280,5 @@ func (r *applicationAccessGrantResource) Delete(ctx context.Context, req resourc
 }
 
 func (r *applicationAccessGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -27,7 +28,8 @@ type applicationAccessGrantApprovalResource struct {
 	provider AxualProvider
 }
 type GrantApprovalData struct {
-	ApplicationAccessGrant types.String `tfsdk:"application_access_grant"`
+	ApplicationAccessGrant types.String   `tfsdk:"application_access_grant"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *applicationAccessGrantApprovalResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,6 +48,9 @@ func (r *applicationAccessGrantApprovalResource) Schema(ctx context.Context, req
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": createDeleteTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -59,7 +64,10 @@ func (r *applicationAccessGrantApprovalResource) Create(ctx context.Context, req
 		return
 	}
 
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.ApplicationAccessGrant.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.ApplicationAccessGrant.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get Application Access Grant", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -67,7 +75,7 @@ func (r *applicationAccessGrantApprovalResource) Create(ctx context.Context, req
 
 	if applicationAccessGrant.Links.Approve.Href != "" {
 		tflog.Info(ctx, "Approving Application Access Grant")
-		err := r.provider.client.ApproveGrant(data.ApplicationAccessGrant.ValueString())
+		err := r.provider.client.ApproveGrant(ctx, data.ApplicationAccessGrant.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to approve grant", fmt.Sprintf("Error message: %s", err.Error()))
 			return
@@ -147,7 +155,7 @@ func (r *applicationAccessGrantApprovalResource) Read(ctx context.Context, req r
 
 	tflog.Info(ctx, fmt.Sprintf("Reading Application Access Grant Approval for grant: %s", data.ApplicationAccessGrant.ValueString()))
 
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.ApplicationAccessGrant.ValueString())
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.ApplicationAccessGrant.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Application Access Grant not found, removing approval from state. Id: %s", data.ApplicationAccessGrant.ValueString()))
@@ -173,6 +181,9 @@ func (r *applicationAccessGrantApprovalResource) Read(ctx context.Context, req r
 }
 
 func (r *applicationAccessGrantApprovalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if updateTimeouts(ctx, req, resp) {
+		return
+	}
 	resp.Diagnostics.AddError(
 		"Application Access Grant Approval cannot be Edited",
 		"Please delete the Approval to revoke Approval, then create a new Approval for a different grant",
@@ -190,14 +201,17 @@ func (r *applicationAccessGrantApprovalResource) Delete(ctx context.Context, req
 		return
 	}
 
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.ApplicationAccessGrant.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.ApplicationAccessGrant.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get Application Access Grant", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
 
 	if applicationAccessGrant.Links.Revoke.Href != "" {
-		err := r.provider.client.RevokeOrDenyGrant(data.ApplicationAccessGrant.ValueString(), "Revoked in terraform")
+		err := r.provider.client.RevokeOrDenyGrant(ctx, data.ApplicationAccessGrant.ValueString(), "Revoked in terraform")
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to revoke approval for application access grant", fmt.Sprintf("Error message: %s", err.Error()))
 			return
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,8 +29,9 @@ type applicationAccessGrantRejectionResource struct {
 }
 
 type GrantRejectionData struct {
-	ApplicationAccessGrant types.String `tfsdk:"application_access_grant"`
-	Reason                 types.String `tfsdk:"reason"`
+	ApplicationAccessGrant types.String   `tfsdk:"application_access_grant"`
+	Reason                 types.String   `tfsdk:"reason"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *applicationAccessGrantRejectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +54,9 @@ func (r *applicationAccessGrantRejectionResource) Schema(ctx context.Context, re
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": createDeleteTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -65,7 +70,10 @@ func (r *applicationAccessGrantRejectionResource) Create(ctx context.Context, re
 		return
 	}
 
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.ApplicationAccessGrant.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.ApplicationAccessGrant.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get Application Access Grant", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -73,7 +81,7 @@ func (r *applicationAccessGrantRejectionResource) Create(ctx context.Context, re
 
 	if applicationAccessGrant.Links.Deny.Href != "" {
 
-		err := r.provider.client.RevokeOrDenyGrant(data.ApplicationAccessGrant.ValueString(), data.Reason.ValueString())
+		err := r.provider.client.RevokeOrDenyGrant(ctx, data.ApplicationAccessGrant.ValueString(), data.Reason.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to reject grant", fmt.Sprintf("Error message: %s", err.Error()))
 			return
@@ -151,7 +159,7 @@ func (r *applicationAccessGrantRejectionResource) Read(ctx context.Context, req
 
 	tflog.Info(ctx, fmt.Sprintf("Reading Application Access Grant Rejection for grant: %s", data.ApplicationAccessGrant.ValueString()))
 
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(data.ApplicationAccessGrant.ValueString())
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrant(ctx, data.ApplicationAccessGrant.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Application Access Grant not found, removing rejection from state. Id: %s", data.ApplicationAccessGrant.ValueString()))
@@ -181,6 +189,9 @@ func (r *applicationAccessGrantRejectionResource) Read(ctx context.Context, req
 }
 
 func (r *applicationAccessGrantRejectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if updateTimeouts(ctx, req, resp) {
+		return
+	}
 	resp.Diagnostics.AddError(
 		"Application Access Grant Rejection cannot be Edited",
 		"Please delete the Application Access Grant Approval to revoke Approval",
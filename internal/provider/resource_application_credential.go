@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -52,6 +53,7 @@ type applicationCredentialResourceData struct {
 	Description   types.String   `tfsdk:"description"`
 	AuthProvider  types.String   `tfsdk:"auth_provider"`
 	Types         []types.String `tfsdk:"types"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *applicationCredentialResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -113,6 +115,9 @@ func (r *applicationCredentialResource) Schema(ctx context.Context, req resource
 				ElementType:         types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": createDeleteTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -124,13 +129,17 @@ func (r *applicationCredentialResource) Create(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	applicationCredentialCreateRequest, err := createApplicationCredentialRequestFromData(ctx, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating CREATE request struct for application credential resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Create application credential request %q", applicationCredentialCreateRequest))
-	applicationCredential, err := r.provider.client.CreateApplicationCredential(applicationCredentialCreateRequest)
+	applicationCredential, err := r.provider.client.CreateApplicationCredential(ctx, applicationCredentialCreateRequest)
 
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for application credential resource", fmt.Sprintf("Error message: %s %s", applicationCredential, err))
@@ -144,7 +153,7 @@ func (r *applicationCredentialResource) Create(ctx context.Context, req resource
 
 	// The Create API response does not include the credential ID.
 	// Look up the newly created credential by application+environment and match by username to populate the ID.
-	credentials, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(data.ApplicationId.ValueString(), data.EnvironmentId.ValueString())
+	credentials, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(ctx, data.ApplicationId.ValueString(), data.EnvironmentId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error looking up credential ID after creation", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -176,7 +185,7 @@ func (r *applicationCredentialResource) Read(ctx context.Context, req resource.R
 	// Use GET /application_credentials/{uid} to read the full credential.
 	if data.ApplicationId.ValueString() == "" {
 		tflog.Info(ctx, "Import mode: reading credential by ID")
-		credential, err := r.provider.client.ReadApplicationCredential(data.Id.ValueString())
+		credential, err := r.provider.client.ReadApplicationCredential(ctx, data.Id.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application credential, got error: %s", err))
 			return
@@ -190,7 +199,7 @@ func (r *applicationCredentialResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	credentials, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(data.ApplicationId.ValueString(), data.EnvironmentId.ValueString())
+	credentials, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(ctx, data.ApplicationId.ValueString(), data.EnvironmentId.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error querying for Application Credential for this application and environment", fmt.Sprintf("Error message: %s", err.Error()))
@@ -236,6 +245,9 @@ func (r *applicationCredentialResource) Read(ctx context.Context, req resource.R
 }
 
 func (r *applicationCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if updateTimeouts(ctx, req, resp) {
+		return
+	}
 	resp.Diagnostics.AddError("Client Error", "API does not allow update of application credential. Please delete and recreate the resource.")
 }
 
@@ -247,6 +259,9 @@ func (r *applicationCredentialResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	var usernameConfig = webclient.NameConfig{
 		Username: data.UserName.ValueString(),
 	}
@@ -258,7 +273,7 @@ func (r *applicationCredentialResource) Delete(ctx context.Context, req resource
 		Configs:       usernameConfig,
 	}
 
-	err := r.provider.client.DeleteApplicationCredential(applicationCredentialDeleteRequest)
+	err := r.provider.client.DeleteApplicationCredential(ctx, applicationCredentialDeleteRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete application principal, got error: %s", err))
 		return
@@ -3,13 +3,18 @@ package provider
 import (
 	webclient "axual-webclient"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
-	"time"
+	"slices"
+	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -37,16 +42,23 @@ type applicationDeploymentResource struct {
 }
 
 type ApplicationDeploymentResourceData struct {
-	Id             types.String `tfsdk:"id"`
-	Application    types.String `tfsdk:"application"`
-	Environment    types.String `tfsdk:"environment"`
-	Configs        types.Map    `tfsdk:"configs"`
-	Type           types.String `tfsdk:"type"`
-	Definition     types.String `tfsdk:"definition"`
-	DeploymentSize types.String `tfsdk:"deployment_size"`
-	RestartPolicy  types.String `tfsdk:"restart_policy"`
+	Id                     types.String   `tfsdk:"id"`
+	Application            types.String   `tfsdk:"application"`
+	Environment            types.String   `tfsdk:"environment"`
+	Configs                types.Map      `tfsdk:"configs"`
+	SecretConfigsWo        types.Map      `tfsdk:"secret_configs_wo"`
+	SecretConfigsWoVersion types.Int64    `tfsdk:"secret_configs_wo_version"`
+	Type                   types.String   `tfsdk:"type"`
+	Definition             types.String   `tfsdk:"definition"`
+	DeploymentSize         types.String   `tfsdk:"deployment_size"`
+	RestartPolicy          types.String   `tfsdk:"restart_policy"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
+// secretConfigKeysKey is the key of the private state holding the keys of secret_configs_wo, which Read leaves
+// out of configs, as the values of write-only attributes are never stored.
+const secretConfigKeysKey = "secret_config_keys"
+
 func (r *applicationDeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_application_deployment"
 }
@@ -83,6 +95,23 @@ func (r *applicationDeploymentResource) Schema(ctx context.Context, req resource
 				ElementType:         types.StringType,
 				Sensitive:           true,
 			},
+			"secret_configs_wo": schema.MapAttribute{
+				MarkdownDescription: "Write-only connector configs, such as passwords, that are sent to the API together with `configs` but never stored in the plan or state. The keys must not be in `configs`. Requires Terraform 1.11 or later and `secret_configs_wo_version`, as Terraform cannot detect changes of a write-only attribute. Only for Connector deployments",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("secret_configs_wo_version")),
+				},
+			},
+			"secret_configs_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `secret_configs_wo`. Change it to send new secret configs to the API, which restarts the deployment",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("secret_configs_wo")),
+				},
+			},
 			"definition": schema.StringAttribute{
 				MarkdownDescription: "KSML definition for Application Deployment. Required for KSML deployments. This field is Sensitive and will not be displayed in server log outputs when using Terraform commands.",
 				Optional:            true,
@@ -110,6 +139,9 @@ func (r *applicationDeploymentResource) Schema(ctx context.Context, req resource
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -118,12 +150,18 @@ func (r *applicationDeploymentResource) Create(ctx context.Context, req resource
 
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	// Write-only attributes are null in the plan, so the secret configs are taken from the config.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_configs_wo"), &data.SecretConfigsWo)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultDeploymentTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Fetch application to determine its type
-	application, err := r.provider.client.GetApplication(data.Application.ValueString())
+	application, err := r.provider.client.GetApplication(ctx, data.Application.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error fetching application", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -132,13 +170,13 @@ func (r *applicationDeploymentResource) Create(ctx context.Context, req resource
 	// Set the type based on the application's type
 	data.Type = types.StringValue(application.ApplicationType)
 
-	applicationURL := fmt.Sprintf("%s/applications/%v", r.provider.client.ApiURL, data.Application.ValueString())
-	environmentURL := fmt.Sprintf("%s/environments/%v", r.provider.client.ApiURL, data.Environment.ValueString())
+	applicationURL := fmt.Sprintf("%s/applications/%v", r.provider.client.BaseURL(), data.Application.ValueString())
+	environmentURL := fmt.Sprintf("%s/environments/%v", r.provider.client.BaseURL(), data.Environment.ValueString())
 
 	// we count if there is at least one authentication defined for these application and environment
 	authenticationCount := 0
 	// We check if Application Principal exists for this environment and application
-	applicationPrincipalsResponse, err := r.provider.client.FindApplicationPrincipalByApplicationAndEnvironment(applicationURL, environmentURL)
+	applicationPrincipalsResponse, err := r.provider.client.FindApplicationPrincipalByApplicationAndEnvironment(ctx, applicationURL, environmentURL)
 	if err != nil {
 		resp.Diagnostics.AddError("Error querying for Application Principal for this application and environment", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -146,7 +184,7 @@ func (r *applicationDeploymentResource) Create(ctx context.Context, req resource
 	authenticationCount += len(applicationPrincipalsResponse.Embedded.ApplicationPrincipalResponses)
 	if isKSML(data.Type.ValueString()) {
 		// For KSML applications, we check if Application Credential exists for this environment and application
-		applicationCredentialsResponse, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(applicationURL, environmentURL)
+		applicationCredentialsResponse, err := r.provider.client.FindApplicationCredentialByApplicationAndEnvironment(ctx, applicationURL, environmentURL)
 		if err != nil {
 			resp.Diagnostics.AddError("Error querying for Application Credential for this application and environment", fmt.Sprintf("Error message: %s", err.Error()))
 			return
@@ -186,7 +224,7 @@ func (r *applicationDeploymentResource) Create(ctx context.Context, req resource
 		EnvironmentId: data.Environment.ValueString(),
 		Statuses:      "APPROVED",
 	}
-	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrantsByAttributes(accessGrantRequest)
+	applicationAccessGrant, err := r.provider.client.GetApplicationAccessGrantsByAttributes(ctx, accessGrantRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Error querying for Application Access Grant for this application and environment", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -203,21 +241,22 @@ func (r *applicationDeploymentResource) Create(ctx context.Context, req resource
 		resp.Diagnostics.AddError("Error creating request struct for application deployment resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
-	_, err = r.provider.client.CreateApplicationDeployment(ApplicationDeploymentRequest)
+	_, err = r.provider.client.CreateApplicationDeployment(ctx, ApplicationDeploymentRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("CREATE request error for application deployment resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "CREATE request error for application deployment resource", fmt.Sprintf("Error message: %s", err.Error()), err, "configs")
 		return
 	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, secretConfigKeysKey, secretConfigKeys(data.SecretConfigsWo))...)
 
 	// We search for the Application Deployment we just created because we need to save its UID, because creating it did not respond with UID.
-	ApplicationDeploymentFindByApplicationAndEnvironmentResponse, err := r.provider.client.FindApplicationDeploymentByApplicationAndEnvironment(applicationURL, environmentURL)
+	ApplicationDeploymentFindByApplicationAndEnvironmentResponse, err := r.provider.client.FindApplicationDeploymentByApplicationAndEnvironment(ctx, applicationURL, environmentURL)
 	if err != nil {
 		resp.Diagnostics.AddError("Error finding application deployment", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
 
 	// Map response to data
-	err = mapApplicationDeploymentByApplicationAndEnvironmentResponseToData(ctx, &data, ApplicationDeploymentFindByApplicationAndEnvironmentResponse)
+	err = mapApplicationDeploymentByApplicationAndEnvironmentResponseToData(ctx, &data, ApplicationDeploymentFindByApplicationAndEnvironmentResponse, secretKeysOf(data.SecretConfigsWo))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to map Application Deployment, got error: %s", err))
 		return
@@ -232,26 +271,24 @@ func (r *applicationDeploymentResource) Create(ctx context.Context, req resource
 	}
 	tflog.Info(ctx, "State saved immediately after deployment creation")
 
-	// START deployment with retry logic
+	// START deployment, transient failures are retried by the client
 	var applicationStartRequest = webclient.ApplicationDeploymentOperationRequest{
 		Action: "START",
 	}
 
-	err = Retry(3, 10*time.Second, func() error {
-		return r.provider.client.OperateApplicationDeployment(data.Id.ValueString(), "START", applicationStartRequest)
-	})
+	err = r.provider.client.OperateApplicationDeployment(ctx, data.Id.ValueString(), "START", applicationStartRequest)
 	if err != nil {
 		// Check if the error indicates the deployment is already running
 		// This means a previous START succeeded but response timed out
-		if strings.Contains(err.Error(), "Invalid action for this state of deployment") {
+		if errors.Is(err, webclient.InvalidDeploymentStateError) {
 			tflog.Info(ctx, "Deployment appears to be already running - previous START may have succeeded")
 			return
 		}
 
 		// Other errors - deployment created but START failed
 		resp.Diagnostics.AddWarning(
-			"Deployment created but START failed after retries",
-			fmt.Sprintf("The deployment was created and saved to state, but could not be started after 3 attempts: %s. "+
+			"Deployment created but START failed",
+			fmt.Sprintf("The deployment was created and saved to state, but could not be started: %s. "+
 				"Run 'terraform apply' again to retry starting the deployment.", err))
 		return
 	}
@@ -269,9 +306,9 @@ func (r *applicationDeploymentResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	applicationWithUrl := fmt.Sprintf("%s/applications/%v", r.provider.client.ApiURL, data.Application.ValueString())
-	environmentWithUrl := fmt.Sprintf("%s/environments/%v", r.provider.client.ApiURL, data.Environment.ValueString())
-	ApplicationDeploymentFindByApplicationAndEnvironmentResponse, err := r.provider.client.FindApplicationDeploymentByApplicationAndEnvironment(applicationWithUrl, environmentWithUrl)
+	applicationWithUrl := fmt.Sprintf("%s/applications/%v", r.provider.client.BaseURL(), data.Application.ValueString())
+	environmentWithUrl := fmt.Sprintf("%s/environments/%v", r.provider.client.BaseURL(), data.Environment.ValueString())
+	ApplicationDeploymentFindByApplicationAndEnvironmentResponse, err := r.provider.client.FindApplicationDeploymentByApplicationAndEnvironment(ctx, applicationWithUrl, environmentWithUrl)
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to find Application Deployment with ID: %s, got error: %s", data.Id.ValueString(), err))
@@ -280,7 +317,18 @@ func (r *applicationDeploymentResource) Read(ctx context.Context, req resource.R
 		}
 		return
 	}
-	err = mapApplicationDeploymentByApplicationAndEnvironmentResponseToData(ctx, &data, ApplicationDeploymentFindByApplicationAndEnvironmentResponse)
+	// The private state of an imported deployment holds no secret keys, so its secrets are read into configs
+	// until it is applied with secret_configs_wo, as documented for the resource.
+	secretKeysJSON, diags := req.Private.GetKey(ctx, secretConfigKeysKey)
+	resp.Diagnostics.Append(diags...)
+	var secretKeys []string
+	if len(secretKeysJSON) > 0 {
+		if err := json.Unmarshal(secretKeysJSON, &secretKeys); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the keys of the secret configs, got error: %s", err))
+			return
+		}
+	}
+	err = mapApplicationDeploymentByApplicationAndEnvironmentResponseToData(ctx, &data, ApplicationDeploymentFindByApplicationAndEnvironmentResponse, secretKeys)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to map Application Deployment, got error: %s", err))
 		return
@@ -305,8 +353,11 @@ func (r *applicationDeploymentResource) Update(ctx context.Context, req resource
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, planData.Timeouts.Update, defaultDeploymentTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get the current status of the application deployment
-	applicationDeploymentStatus, err := r.provider.client.GetApplicationDeploymentStatus(planData.Id.ValueString())
+	applicationDeploymentStatus, err := r.provider.client.GetApplicationDeploymentStatus(ctx, planData.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get Application Deployment status, got error: %s", err))
 		return
@@ -317,45 +368,50 @@ func (r *applicationDeploymentResource) Update(ctx context.Context, req resource
 		var applicationStopRequest = webclient.ApplicationDeploymentOperationRequest{
 			Action: "STOP",
 		}
-		err := r.provider.client.OperateApplicationDeployment(planData.Id.ValueString(), "STOP", applicationStopRequest)
+		err := r.provider.client.OperateApplicationDeployment(ctx, planData.Id.ValueString(), "STOP", applicationStopRequest)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stop Application, got error: %s", err))
 			return
 		}
 	}
 
+	// Write-only attributes are null in the plan, so the secret configs are taken from the config.
+	planData.SecretConfigsWo = configData.SecretConfigsWo
 	ApplicationDeploymentUpdateRequest, err := createApplicationUpdateDeploymentRequestFromData(ctx, &planData)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating request struct for application deployment resource", fmt.Sprintf("Error message: %s", err.Error()))
+		return
+	}
 
-	_, err = r.provider.client.UpdateApplicationDeployment(planData.Id.ValueString(), ApplicationDeploymentUpdateRequest)
+	_, err = r.provider.client.UpdateApplicationDeployment(ctx, planData.Id.ValueString(), ApplicationDeploymentUpdateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Application Deployment, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Client Error", fmt.Sprintf("Unable to update Application Deployment, got error: %s", err), err, "configs")
 		return
 	}
 	tflog.Info(ctx, "Successfully updated Application Deployment")
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, secretConfigKeysKey, secretConfigKeys(planData.SecretConfigsWo))...)
 
 	diags = resp.State.Set(ctx, &planData)
 	resp.Diagnostics.Append(diags...)
 
-	// START deployment with retry logic
+	// START deployment, transient failures are retried by the client
 	var applicationStartRequest = webclient.ApplicationDeploymentOperationRequest{
 		Action: "START",
 	}
 
-	err = Retry(3, 5*time.Second, func() error {
-		return r.provider.client.OperateApplicationDeployment(planData.Id.ValueString(), "START", applicationStartRequest)
-	})
+	err = r.provider.client.OperateApplicationDeployment(ctx, planData.Id.ValueString(), "START", applicationStartRequest)
 	if err != nil {
 		// Check if the error indicates the deployment is already running
 		// This means a previous START succeeded but response timed out
-		if strings.Contains(err.Error(), "Invalid action for this state of deployment") {
+		if errors.Is(err, webclient.InvalidDeploymentStateError) {
 			tflog.Info(ctx, "Deployment appears to be already running - previous START may have succeeded")
 			return
 		}
 
 		// Other errors - deployment updated but START failed
 		resp.Diagnostics.AddWarning(
-			"Deployment updated but START failed after retries",
-			fmt.Sprintf("The deployment was updated successfully but could not be started after 3 attempts: %s. "+
+			"Deployment updated but START failed",
+			fmt.Sprintf("The deployment was updated successfully but could not be started: %s. "+
 				"Run 'terraform apply' again to retry starting the deployment.", err))
 		return
 	}
@@ -373,8 +429,11 @@ func (r *applicationDeploymentResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeploymentTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	// Get the current status of the application deployment
-	applicationDeploymentStatus, err := r.provider.client.GetApplicationDeploymentStatus(data.Id.ValueString())
+	applicationDeploymentStatus, err := r.provider.client.GetApplicationDeploymentStatus(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get Application Deployment status, got error: %s", err))
 		return
@@ -385,14 +444,14 @@ func (r *applicationDeploymentResource) Delete(ctx context.Context, req resource
 		var applicationStopRequest = webclient.ApplicationDeploymentOperationRequest{
 			Action: "STOP",
 		}
-		err := r.provider.client.OperateApplicationDeployment(data.Id.ValueString(), "STOP", applicationStopRequest)
+		err := r.provider.client.OperateApplicationDeployment(ctx, data.Id.ValueString(), "STOP", applicationStopRequest)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to stop Application, got error: %s", err))
 			return
 		}
 	}
 
-	err = r.provider.client.DeleteApplicationDeployment(data.Id.ValueString())
+	err = r.provider.client.DeleteApplicationDeployment(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Application Deployment, got error: %s", err))
 		return
@@ -402,7 +461,8 @@ func (r *applicationDeploymentResource) Delete(ctx context.Context, req resource
 func mapApplicationDeploymentByApplicationAndEnvironmentResponseToData(
 	ctx context.Context,
 	data *ApplicationDeploymentResourceData,
-	applicationDeploymentResponse *webclient.ApplicationDeploymentFindByApplicationAndEnvironmentResponse) error {
+	applicationDeploymentResponse *webclient.ApplicationDeploymentFindByApplicationAndEnvironmentResponse,
+	secretKeys []string) error {
 	if len(applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses) == 0 {
 		return fmt.Errorf("error processing mapping application deployment response, no application deployment found for the application and environment")
 	} else if len(applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses) > 1 {
@@ -411,7 +471,7 @@ func mapApplicationDeploymentByApplicationAndEnvironmentResponseToData(
 		data.Id = types.StringValue(applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Uid)
 		data.Environment = types.StringValue(applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Embedded.Environment.Uid)
 		data.Application = types.StringValue(applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Embedded.Application.Uid)
-		mapResponseConfigsToData(ctx, data, applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Embedded.Application.ApplicationType, applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Configs)
+		mapResponseConfigsToData(ctx, data, applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Embedded.Application.ApplicationType, applicationDeploymentResponse.Embedded.ApplicationDeploymentResponses[0].Configs, secretKeys)
 		return nil
 	}
 }
@@ -421,7 +481,7 @@ func mapApplicationDeploymentByIdResponseToData(ctx context.Context, data *Appli
 	data.Environment = types.StringValue(applicationDeploymentResponse.Embedded.Environment.Uid)
 	data.Application = types.StringValue(applicationDeploymentResponse.Embedded.Application.Uid)
 
-	mapResponseConfigsToData(ctx, data, applicationDeploymentResponse.Embedded.Application.ApplicationType, applicationDeploymentResponse.Configs)
+	mapResponseConfigsToData(ctx, data, applicationDeploymentResponse.Embedded.Application.ApplicationType, applicationDeploymentResponse.Configs, nil)
 }
 
 func createApplicationDeploymentRequestFromData(ctx context.Context, data *ApplicationDeploymentResourceData) (webclient.ApplicationDeploymentCreateRequest, error) {
@@ -437,7 +497,11 @@ func createApplicationDeploymentRequestFromData(ctx context.Context, data *Appli
 		Configs:     configs,
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Application request completed: %q", ApplicationDeploymentRequest))
+	tflog.Info(ctx, "Application deployment request completed", map[string]interface{}{
+		"application": ApplicationDeploymentRequest.Application,
+		"environment": ApplicationDeploymentRequest.Environment,
+		"configs":     len(ApplicationDeploymentRequest.Configs),
+	})
 	return ApplicationDeploymentRequest, nil
 }
 
@@ -452,13 +516,15 @@ func createApplicationUpdateDeploymentRequestFromData(ctx context.Context, data
 		Configs: configs,
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Application update request completed: %q", ApplicationDeploymentUpdateRequest))
+	tflog.Info(ctx, "Application deployment update request completed", map[string]interface{}{
+		"configs": len(ApplicationDeploymentUpdateRequest.Configs),
+	})
 	return ApplicationDeploymentUpdateRequest, nil
 }
 
 func (r *applicationDeploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 
-	applicationDeployment, err := r.provider.client.GetApplicationDeployment(req.ID)
+	applicationDeployment, err := r.provider.client.GetApplicationDeployment(ctx, req.ID)
 
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
@@ -493,8 +559,10 @@ func (r *applicationDeploymentResource) ImportState(ctx context.Context, req res
 
 	tflog.Info(ctx, fmt.Sprintf("Successfully imported Application Deployment with ID: %s", data.Id.ValueString()))
 
-	// Set the state with the imported data
-	diags := resp.State.Set(ctx, &data)
+	// Set the state with the imported data, keeping the empty timeouts of the import
+	diags := resp.State.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -516,6 +584,9 @@ func createConfigsForDeploymentType(data *ApplicationDeploymentResourceData) (ma
 	}
 
 	if isKSML(deploymentType) {
+		if len(data.SecretConfigsWo.Elements()) > 0 {
+			return configs, fmt.Errorf("secret_configs_wo is only supported for Connector deployments")
+		}
 		// For KSML deployments, add KSML-specific configs
 		if !data.Definition.IsNull() && !data.Definition.IsUnknown() {
 			configs["ksml_definition"] = data.Definition.ValueString()
@@ -535,11 +606,38 @@ func createConfigsForDeploymentType(data *ApplicationDeploymentResourceData) (ma
 			}
 			configs[key] = strValue.ValueString()
 		}
+		for key, value := range data.SecretConfigsWo.Elements() {
+			strValue, ok := value.(types.String)
+			if !ok {
+				return configs, fmt.Errorf("type assertion to types.String failed for key: %s", key)
+			}
+			if _, ok := configs[key]; ok {
+				return configs, fmt.Errorf("config %s is set in both configs and secret_configs_wo", key)
+			}
+			configs[key] = strValue.ValueString()
+		}
 	}
 	return configs, nil
 }
 
-func mapResponseConfigsToData(ctx context.Context, data *ApplicationDeploymentResourceData, deploymentType string, responseConfigs []webclient.Config) {
+// secretKeysOf returns the keys of the secret configs.
+func secretKeysOf(secretConfigs types.Map) []string {
+	keys := make([]string, 0, len(secretConfigs.Elements()))
+	for key := range secretConfigs.Elements() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// secretConfigKeys returns the keys of the secret configs for the private state.
+func secretConfigKeys(secretConfigs types.Map) []byte {
+	keys, _ := json.Marshal(secretKeysOf(secretConfigs))
+	return keys
+}
+
+// mapResponseConfigsToData maps the configs of the response to data, leaving out the secretKeys of secret_configs_wo.
+func mapResponseConfigsToData(ctx context.Context, data *ApplicationDeploymentResourceData, deploymentType string, responseConfigs []webclient.Config, secretKeys []string) {
 	// Initialize the map for configs
 	configs := make(map[string]attr.Value)
 
@@ -555,7 +653,9 @@ func mapResponseConfigsToData(ctx context.Context, data *ApplicationDeploymentRe
 		case "ksml_restart_policy":
 			ksmlRestartPolicy = config.ConfigValue
 		default:
-			configs[config.ConfigKey] = types.StringValue(config.ConfigValue)
+			if !slices.Contains(secretKeys, config.ConfigKey) {
+				configs[config.ConfigKey] = types.StringValue(config.ConfigValue)
+			}
 		}
 	}
 
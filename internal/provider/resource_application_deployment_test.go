@@ -0,0 +1,91 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringMap(values map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestCreateConfigsForDeploymentTypeWithSecretConfigs(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		deployment    ApplicationDeploymentResourceData
+		wantConfigs   map[string]string
+		wantErrorText string
+	}{
+		{
+			desc: "secret configs are sent together with the configs",
+			deployment: ApplicationDeploymentResourceData{
+				Type:            types.StringValue("Connector"),
+				Configs:         stringMap(map[string]string{"connection.user": "app"}),
+				SecretConfigsWo: stringMap(map[string]string{"connection.password": "secret"}),
+			},
+			wantConfigs: map[string]string{"connection.user": "app", "connection.password": "secret"},
+		},
+		{
+			desc: "a key in both configs and secret configs is rejected",
+			deployment: ApplicationDeploymentResourceData{
+				Type:            types.StringValue("Connector"),
+				Configs:         stringMap(map[string]string{"connection.password": "visible"}),
+				SecretConfigsWo: stringMap(map[string]string{"connection.password": "secret"}),
+			},
+			wantErrorText: "config connection.password is set in both configs and secret_configs_wo",
+		},
+		{
+			desc: "secret configs are rejected for KSML deployments",
+			deployment: ApplicationDeploymentResourceData{
+				Type:            types.StringValue("Ksml"),
+				Definition:      types.StringValue("streams: {}"),
+				SecretConfigsWo: stringMap(map[string]string{"password": "secret"}),
+			},
+			wantErrorText: "secret_configs_wo is only supported for Connector deployments",
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			configs, err := createConfigsForDeploymentType(&c.deployment)
+			if c.wantErrorText != "" {
+				if err == nil || err.Error() != c.wantErrorText {
+					t.Fatalf("expected error %q, got %v", c.wantErrorText, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(configs) != len(c.wantConfigs) {
+				t.Fatalf("expected configs %v, got %v", c.wantConfigs, configs)
+			}
+			for key, value := range c.wantConfigs {
+				if configs[key] != value {
+					t.Errorf("expected %s=%s, got %q", key, value, configs[key])
+				}
+			}
+		})
+	}
+}
+
+func TestMapResponseConfigsToDataLeavesOutSecretConfigs(t *testing.T) {
+	data := ApplicationDeploymentResourceData{}
+	responseConfigs := []webclient.Config{
+		{ConfigKey: "connection.user", ConfigValue: "app"},
+		{ConfigKey: "connection.password", ConfigValue: "secret"},
+	}
+	mapResponseConfigsToData(context.Background(), &data, "Connector", responseConfigs, []string{"connection.password"})
+
+	want := stringMap(map[string]string{"connection.user": "app"})
+	if !data.Configs.Equal(want) {
+		t.Errorf("expected configs %s, got %s", want, data.Configs)
+	}
+}
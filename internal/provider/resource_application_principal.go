@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -32,13 +36,16 @@ type applicationPrincipalResource struct {
 }
 
 type applicationPrincipalResourceData struct {
-	Principal   types.String `tfsdk:"principal"`
-	PrivateKey  types.String `tfsdk:"private_key"`
-	Application types.String `tfsdk:"application"`
-	Environment types.String `tfsdk:"environment"`
-	Custom      types.Bool   `tfsdk:"custom"`
-	Active      types.Bool   `tfsdk:"active"`
-	Id          types.String `tfsdk:"id"`
+	Principal           types.String   `tfsdk:"principal"`
+	PrivateKey          types.String   `tfsdk:"private_key"`
+	PrivateKeyWo        types.String   `tfsdk:"private_key_wo"`
+	PrivateKeyWoVersion types.Int64    `tfsdk:"private_key_wo_version"`
+	Application         types.String   `tfsdk:"application"`
+	Environment         types.String   `tfsdk:"environment"`
+	Custom              types.Bool     `tfsdk:"custom"`
+	Active              types.Bool     `tfsdk:"active"`
+	Id                  types.String   `tfsdk:"id"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *applicationPrincipalResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,7 +75,7 @@ func (m trimSpaceSemanticallyEqual) PlanModifyString(_ context.Context, req plan
 	}
 }
 
-// isCertificateChanging returns true when principal or private_key differs between plan and state,
+// isCertificateChanging returns true when principal, private_key or private_key_wo_version differs between plan and state,
 // indicating a certificate rotation is in progress. Used by plan modifiers for id and active
 // to mark those attributes as (known after apply) so the plan/apply cycle is consistent.
 func isCertificateChanging(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) bool {
@@ -88,7 +95,14 @@ func isCertificateChanging(ctx context.Context, plan tfsdk.Plan, state tfsdk.Sta
 	state.GetAttribute(ctx, path.Root("private_key"), &statePrivateKey)
 	// Compare like principal: ignore leading/trailing whitespace so a trailing newline in a key
 	// file does not show as a phantom rotation in the plan (apply trims too, so nothing changes).
-	return strings.TrimSpace(planPrivateKey.ValueString()) != strings.TrimSpace(statePrivateKey.ValueString())
+	if strings.TrimSpace(planPrivateKey.ValueString()) != strings.TrimSpace(statePrivateKey.ValueString()) {
+		return true
+	}
+	// private_key_wo is never in the plan or state, so its version stands in for it.
+	var planVersion, stateVersion types.Int64
+	plan.GetAttribute(ctx, path.Root("private_key_wo_version"), &planVersion)
+	state.GetAttribute(ctx, path.Root("private_key_wo_version"), &stateVersion)
+	return !planVersion.Equal(stateVersion)
 }
 
 // unknownWhenCertChangesString marks a string attribute as (known after apply)
@@ -130,6 +144,25 @@ func (r *applicationPrincipalResource) Schema(ctx context.Context, req resource.
 					// file()) so they don't surface as a misleading rotation in the plan.
 					trimSpaceSemanticallyEqual{},
 				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key_wo")),
+				},
+			},
+			"private_key_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only variant of `private_key`: the private key is sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later and `private_key_wo_version`, as Terraform cannot detect changes of a write-only attribute",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("private_key_wo_version")),
+				},
+			},
+			"private_key_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `private_key_wo`. Change it together with `principal` to rotate the certificate with the new `private_key_wo`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("private_key_wo")),
+				},
 			},
 			"application": schema.StringAttribute{
 				MarkdownDescription: "A valid UID of an existing application",
@@ -162,6 +195,9 @@ func (r *applicationPrincipalResource) Schema(ctx context.Context, req resource.
 				MarkdownDescription: "A boolean identifying whether we are creating a custom principal. If true, the custom principal will be stored in `principal` property. Custom principal allows an application with SASL+OAUTHBEARER to produce/consume a topic. Custom Application Principal certificate is used to authenticate your application with an IAM provider using the custom ApplicationPrincipal as Client ID",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -175,6 +211,9 @@ func (r *applicationPrincipalResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	applicationPrincipalRequest, err := createApplicationPrincipalRequestFromData(ctx, &data, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating CREATE request struct for application principal resource", fmt.Sprintf("Error message: %s", err.Error()))
@@ -185,18 +224,18 @@ func (r *applicationPrincipalResource) Create(ctx context.Context, req resource.
 		"environment": applicationPrincipalRequest[0].Environment,
 		"custom":      applicationPrincipalRequest[0].Custom,
 	})
-	applicationPrincipal, err := r.provider.client.CreateApplicationPrincipal(applicationPrincipalRequest)
+	applicationPrincipal, err := r.provider.client.CreateApplicationPrincipal(ctx, applicationPrincipalRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for application principal resource", fmt.Sprintf("Error message: %s %s", applicationPrincipal, err))
 		return
 	}
 
 	var trimmedResponse = strings.Trim(string(applicationPrincipal), "\"")
-	returnedUid := strings.ReplaceAll(trimmedResponse, fmt.Sprintf("%s/%s", r.provider.client.ApiURL, "application_principals/"), "")
+	returnedUid := strings.ReplaceAll(trimmedResponse, fmt.Sprintf("%s/%s", r.provider.client.BaseURL(), "application_principals/"), "")
 
 	data.Id = types.StringValue(returnedUid)
 
-	application, err := r.provider.client.GetApplication(data.Application.ValueString())
+	application, err := r.provider.client.GetApplication(ctx, data.Application.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application to determine type, got error: %s", err))
 		return
@@ -208,7 +247,7 @@ func (r *applicationPrincipalResource) Create(ctx context.Context, req resource.
 	// and produce a perma-diff on every plan (warnActiveOnNonConnector already flags the no-op).
 	if application.ApplicationType == "Connector" {
 		if boolTrue(data.Active) {
-			err = r.provider.client.ActivateApplicationPrincipal(returnedUid)
+			err = r.provider.client.ActivateApplicationPrincipal(ctx, returnedUid)
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to activate application principal, got error: %s", err))
 				return
@@ -235,7 +274,7 @@ func (r *applicationPrincipalResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	applicationPrincipal, err := r.provider.client.ReadApplicationPrincipal(data.Id.ValueString())
+	applicationPrincipal, err := r.provider.client.ReadApplicationPrincipal(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Error(ctx, fmt.Sprintf("Application Principal not found. Id: %s", data.Id.ValueString()))
@@ -258,12 +297,17 @@ func (r *applicationPrincipalResource) Update(ctx context.Context, req resource.
 	var state, plan applicationPrincipalResourceData
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only attributes are null in the plan, so the private key is taken from the config.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private_key_wo"), &plan.PrivateKeyWo)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	oldId := state.Id.ValueString()
-	application, err := r.provider.client.GetApplication(plan.Application.ValueString())
+	application, err := r.provider.client.GetApplication(ctx, plan.Application.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read application to determine type, got error: %s", err))
 		return
@@ -274,7 +318,8 @@ func (r *applicationPrincipalResource) Update(ctx context.Context, req resource.
 	// Skip rotation — POST with the same fingerprint returns errmsg.duplicate.principal.
 	// No deactivate API exists; active=false is a write-only intent (atomic swap by activating another principal).
 	certUnchanged := strings.TrimSpace(plan.Principal.ValueString()) == strings.TrimSpace(state.Principal.ValueString()) &&
-		strings.TrimSpace(plan.PrivateKey.ValueString()) == strings.TrimSpace(state.PrivateKey.ValueString())
+		strings.TrimSpace(plan.PrivateKey.ValueString()) == strings.TrimSpace(state.PrivateKey.ValueString()) &&
+		plan.PrivateKeyWoVersion.Equal(state.PrivateKeyWoVersion)
 	if certUnchanged {
 		tflog.Info(ctx, fmt.Sprintf("Update application principal: cert unchanged for %s, no rotation", oldId))
 		active, err := r.resolveActivation(ctx, oldId, plan, application)
@@ -289,19 +334,23 @@ func (r *applicationPrincipalResource) Update(ctx context.Context, req resource.
 	}
 
 	// Cert rotation: create new principal, activate (if Connector), delete old.
+	if err := r.provider.client.RequireFeature(webclient.FeaturePrincipalRotation); err != nil {
+		addUnsupportedFeatureError(&resp.Diagnostics, path.Root("principal"), "Rotating the certificate of an application principal", err)
+		return
+	}
 	principalReq, err := createApplicationPrincipalRequestFromData(ctx, &plan, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating UPDATE request struct for application principal resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Update application principal: creating new principal to replace %s", oldId))
-	newPrincipal, err := r.provider.client.CreateApplicationPrincipal(principalReq)
+	newPrincipal, err := r.provider.client.CreateApplicationPrincipal(ctx, principalReq)
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error during application principal update", fmt.Sprintf("Error message: %s %s", newPrincipal, err))
 		return
 	}
 	trimmedResponse := strings.Trim(string(newPrincipal), "\"")
-	newId := strings.ReplaceAll(trimmedResponse, fmt.Sprintf("%s/%s", r.provider.client.ApiURL, "application_principals/"), "")
+	newId := strings.ReplaceAll(trimmedResponse, fmt.Sprintf("%s/%s", r.provider.client.BaseURL(), "application_principals/"), "")
 
 	// Save newId to state immediately, before activation and deletion of the old principal.
 	// The new principal already exists in the API; if a later step fails we must still track it,
@@ -321,7 +370,7 @@ func (r *applicationPrincipalResource) Update(ctx context.Context, req resource.
 		// This works regardless of whether activation was an explicit active=true or an inherited one,
 		// because we recover by restarting the rotation rather than persisting the (unrepresentable)
 		// inherited intent.
-		if delErr := r.provider.client.DeleteApplicationPrincipal(newId); delErr != nil {
+		if delErr := r.provider.client.DeleteApplicationPrincipal(ctx, newId); delErr != nil {
 			// Double failure: activation AND rollback both failed. Keep newId in state (the early save
 			// above) — reverting to oldId would orphan newId and, worse, make the next apply rotate the
 			// same cert into errmsg.duplicate.principal forever. With newId tracked, a retry takes the
@@ -345,7 +394,7 @@ func (r *applicationPrincipalResource) Update(ctx context.Context, req resource.
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting old application principal %s", oldId))
-	if err := r.provider.client.DeleteApplicationPrincipal(oldId); err != nil {
+	if err := r.provider.client.DeleteApplicationPrincipal(ctx, oldId); err != nil {
 		// newId is already in state, so this is not an orphan. Error (mirroring the activation-failure
 		// path) so the failure is loud; the user deletes the old principal manually, then a re-run
 		// sees no diff. State holding newId means this cannot accumulate orphans.
@@ -371,7 +420,10 @@ func (r *applicationPrincipalResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
-	err := r.provider.client.DeleteApplicationPrincipal(data.Id.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteApplicationPrincipal(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete application principal, got error: %s", err))
 		return
@@ -412,7 +464,7 @@ func warnActiveOnNonConnector(diags *diag.Diagnostics, app *webclient.Applicatio
 func (r *applicationPrincipalResource) resolveActivation(ctx context.Context, id string, plan applicationPrincipalResourceData, app *webclient.ApplicationResponse) (types.Bool, error) {
 	if app.ApplicationType == "Connector" && boolTrue(plan.Active) {
 		tflog.Info(ctx, fmt.Sprintf("Activating application principal %s", id))
-		if err := r.provider.client.ActivateApplicationPrincipal(id); err != nil {
+		if err := r.provider.client.ActivateApplicationPrincipal(ctx, id); err != nil {
 			return types.BoolNull(), fmt.Errorf("unable to activate application principal: %w", err)
 		}
 	} else {
@@ -446,7 +498,7 @@ func (r *applicationPrincipalResource) activateRotatedPrincipal(ctx context.Cont
 	} else {
 		// (2) Inherit the old principal's LIVE API status. Read just-before-rotation so external
 		// deactivations are seen (state.Active is unreliable as an API-status proxy here).
-		oldP, err := r.provider.client.ReadApplicationPrincipal(oldId)
+		oldP, err := r.provider.client.ReadApplicationPrincipal(ctx, oldId)
 		if err != nil {
 			return fmt.Errorf("unable to read old principal status before rotation: %w", err)
 		}
@@ -455,7 +507,7 @@ func (r *applicationPrincipalResource) activateRotatedPrincipal(ctx context.Cont
 
 	if shouldActivate {
 		tflog.Info(ctx, fmt.Sprintf("Activating rotated application principal %s", newId))
-		if err := r.provider.client.ActivateApplicationPrincipal(newId); err != nil {
+		if err := r.provider.client.ActivateApplicationPrincipal(ctx, newId); err != nil {
 			return fmt.Errorf("unable to activate rotated application principal: %w", err)
 		}
 	} else {
@@ -474,7 +526,7 @@ func createApplicationPrincipalRequestFromData(ctx context.Context, data *applic
 	if err != nil {
 		return [1]webclient.ApplicationPrincipalRequest{}, err
 	}
-	environment = fmt.Sprintf("%s/%v", r.provider.client.ApiURL, environment)
+	environment = fmt.Sprintf("%s/%v", r.provider.client.BaseURL(), environment)
 
 	rawApplication, err := data.Application.ToTerraformValue(ctx)
 	if err != nil {
@@ -485,7 +537,7 @@ func createApplicationPrincipalRequestFromData(ctx context.Context, data *applic
 	if err != nil {
 		return [1]webclient.ApplicationPrincipalRequest{}, err
 	}
-	application = fmt.Sprintf("%s/applications/%v", r.provider.client.ApiURL, application)
+	application = fmt.Sprintf("%s/applications/%v", r.provider.client.BaseURL(), application)
 
 	var applicationPrincipalRequestArray [1]webclient.ApplicationPrincipalRequest
 	applicationPrincipalRequestArray[0] =
@@ -501,6 +553,9 @@ func createApplicationPrincipalRequestFromData(ctx context.Context, data *applic
 	if !data.PrivateKey.IsNull() {
 		applicationPrincipalRequestArray[0].PrivateKey = strings.TrimSpace(data.PrivateKey.ValueString())
 	}
+	if !data.PrivateKeyWo.IsNull() {
+		applicationPrincipalRequestArray[0].PrivateKey = strings.TrimSpace(data.PrivateKeyWo.ValueString())
+	}
 	return applicationPrincipalRequestArray, err
 }
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -37,21 +38,25 @@ type environmentResource struct {
 	provider AxualProvider
 }
 
+// environmentAPIFields are the attributes the API can report validation errors for.
+var environmentAPIFields = []string{"name", "short_name", "description", "color", "authorization_issuer", "visibility", "owners", "viewers", "retention_time", "instance", "partitions", "properties", "settings"}
+
 type environmentResourceData struct {
-	Name                types.String `tfsdk:"name"`
-	ShortName           types.String `tfsdk:"short_name"`
-	Description         types.String `tfsdk:"description"`
-	Color               types.String `tfsdk:"color"`
-	AuthorizationIssuer types.String `tfsdk:"authorization_issuer"`
-	Visibility          types.String `tfsdk:"visibility"`
-	Owners              types.String `tfsdk:"owners"`
-	Viewers             types.Set    `tfsdk:"viewers"`
-	RetentionTime       types.Int64  `tfsdk:"retention_time"`
-	Instance            types.String `tfsdk:"instance"`
-	Id                  types.String `tfsdk:"id"`
-	Partitions          types.Int64  `tfsdk:"partitions"`
-	Properties          types.Map    `tfsdk:"properties"`
-	Settings            types.Map    `tfsdk:"settings"`
+	Name                types.String   `tfsdk:"name"`
+	ShortName           types.String   `tfsdk:"short_name"`
+	Description         types.String   `tfsdk:"description"`
+	Color               types.String   `tfsdk:"color"`
+	AuthorizationIssuer types.String   `tfsdk:"authorization_issuer"`
+	Visibility          types.String   `tfsdk:"visibility"`
+	Owners              types.String   `tfsdk:"owners"`
+	Viewers             types.Set      `tfsdk:"viewers"`
+	RetentionTime       types.Int64    `tfsdk:"retention_time"`
+	Instance            types.String   `tfsdk:"instance"`
+	Id                  types.String   `tfsdk:"id"`
+	Partitions          types.Int64    `tfsdk:"partitions"`
+	Properties          types.Map      `tfsdk:"properties"`
+	Settings            types.Map      `tfsdk:"settings"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *environmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -174,6 +179,9 @@ func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -187,6 +195,9 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	environmentRequest, err := createEnvironmentRequestFromData(ctx, &data, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating CREATE request struct for environment resource", fmt.Sprintf("Error message: %s", err.Error()))
@@ -205,9 +216,9 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	environmentRequest.Settings = settings
 
 	tflog.Info(ctx, fmt.Sprintf("Create environment request %q", environmentRequest))
-	environment, err := r.provider.client.CreateEnvironment(environmentRequest)
+	environment, err := r.provider.client.CreateEnvironment(ctx, environmentRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("CREATE request error for environment resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "CREATE request error for environment resource", fmt.Sprintf("Error message: %s", err.Error()), err, environmentAPIFields...)
 		return
 	}
 
@@ -228,7 +239,7 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	environment, err := r.provider.client.GetEnvironment(data.Id.ValueString())
+	environment, err := r.provider.client.GetEnvironment(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Environment not found. Id: %s", data.Id.ValueString()))
@@ -256,6 +267,10 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	environmentRequest, err := createEnvironmentRequestFromData(ctx, &data, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating UPDATE request struct for environment resource", fmt.Sprintf("Error message: %s", err.Error()))
@@ -265,9 +280,9 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 	environmentRequest.Settings = processSettings(ctx, req, data)
 
 	tflog.Info(ctx, fmt.Sprintf("Update environment request %q", environmentRequest))
-	environment, err := r.provider.client.UpdateEnvironment(data.Id.ValueString(), environmentRequest)
+	environment, err := r.provider.client.UpdateEnvironment(ctx, data.Id.ValueString(), environmentRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("UPDATE request error for environment resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "UPDATE request error for environment resource", fmt.Sprintf("Error message: %s", err.Error()), err, environmentAPIFields...)
 		return
 	}
 
@@ -288,7 +303,10 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.provider.client.DeleteEnvironment(data.Id.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteEnvironment(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("DELETE request error for environment resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -309,8 +327,8 @@ func createEnvironmentRequestFromData(ctx context.Context, data *environmentReso
 	if err != nil {
 		return webclient.EnvironmentRequest{}, err
 	}
-	owners = fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, owners)
-	instance := fmt.Sprintf("%s/instances/%v", r.provider.client.ApiURL, data.Instance.ValueString())
+	owners = fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), owners)
+	instance := fmt.Sprintf("%s/instances/%v", r.provider.client.BaseURL(), data.Instance.ValueString())
 
 	viewers := []string{}
 	if !data.Viewers.IsNull() {
@@ -321,7 +339,7 @@ func createEnvironmentRequestFromData(ctx context.Context, data *environmentReso
 		}
 
 		for _, viewer := range viewerUIDs {
-			fullURL := fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, viewer)
+			fullURL := fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), viewer)
 			viewers = append(viewers, fullURL)
 		}
 	}
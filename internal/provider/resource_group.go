@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -22,6 +23,7 @@ import (
 
 var _ resource.Resource = &groupResource{}
 var _ resource.ResourceWithImportState = &groupResource{}
+var _ resource.ResourceWithIdentity = &groupResource{}
 
 type groupResourceType struct{}
 
@@ -35,19 +37,27 @@ type groupResource struct {
 	provider AxualProvider
 }
 
+// groupAPIFields are the attributes the API can report validation errors for.
+var groupAPIFields = []string{"name", "email_address", "phone_number", "members", "managers"}
+
 type groupResourceData struct {
-	Name         types.String `tfsdk:"name"`
-	EmailAddress types.String `tfsdk:"email_address"`
-	PhoneNumber  types.String `tfsdk:"phone_number"`
-	Members      types.Set    `tfsdk:"members"`
-	Managers     types.Set    `tfsdk:"managers"`
-	Id           types.String `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	EmailAddress types.String   `tfsdk:"email_address"`
+	PhoneNumber  types.String   `tfsdk:"phone_number"`
+	Members      types.Set      `tfsdk:"members"`
+	Managers     types.Set      `tfsdk:"managers"`
+	Id           types.String   `tfsdk:"id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *groupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_group"
 }
 
+func (r *groupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
 func (r *groupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
@@ -94,6 +104,9 @@ func (r *groupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -107,14 +120,17 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	groupRequest, err := createGroupRequestFromData(ctx, &data, r.provider.client.ApiURL)
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	groupRequest, err := createGroupRequestFromData(ctx, &data, r.provider.client.BaseURL())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating CREATE request struct for group resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
-	group, err := r.provider.client.CreateGroup(groupRequest)
+	group, err := r.provider.client.CreateGroup(ctx, groupRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("CREATE request error for group resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "CREATE request error for group resource", fmt.Sprintf("Error message: %s", err.Error()), err, groupAPIFields...)
 		return
 	}
 
@@ -123,6 +139,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -135,7 +152,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	group, err := r.provider.client.GetGroup(data.Id.ValueString())
+	group, err := r.provider.client.GetGroup(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Group not found. Id: %s", data.Id.ValueString()))
@@ -152,6 +169,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	tflog.Info(ctx, "saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -164,14 +182,17 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	groupRequest, err := createGroupRequestFromData(ctx, &data, r.provider.client.ApiURL)
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	groupRequest, err := createGroupRequestFromData(ctx, &data, r.provider.client.BaseURL())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating UPDATE request struct for group resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
-	group, err := r.provider.client.UpdateGroup(data.Id.ValueString(), groupRequest)
+	group, err := r.provider.client.UpdateGroup(ctx, data.Id.ValueString(), groupRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update group, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Client Error", fmt.Sprintf("Unable to update group, got error: %s", err), err, groupAPIFields...)
 		return
 	}
 
@@ -179,6 +200,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -191,9 +213,12 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("delete request for group %q", data.Id.ValueString()))
 
-	err := r.provider.client.DeleteGroup(data.Id.ValueString())
+	err := r.provider.client.DeleteGroup(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete group, got error: %s", err))
 		return
@@ -203,7 +228,7 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func mapGroupResponseToData(ctx context.Context, data *groupResourceData, group *webclient.GroupResponse) {
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	webclient "axual-webclient"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type fakeGroupsAPI struct {
+	fakeAPI
+	groups    map[string]*webclient.GroupResponse
+	created   []webclient.GroupRequest
+	deleted   []string
+	createErr error
+}
+
+func (f *fakeGroupsAPI) CreateGroup(ctx context.Context, group webclient.GroupRequest) (*webclient.GroupResponse, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created = append(f.created, group)
+	response := &webclient.GroupResponse{Name: group.Name, Uid: "group-uid"}
+	for range group.Members {
+		response.Embedded.Members = append(response.Embedded.Members, struct {
+			Uid string `json:"uid"`
+		}{Uid: "user-uid"})
+	}
+	return response, nil
+}
+
+func (f *fakeGroupsAPI) GetGroup(ctx context.Context, id string) (*webclient.GroupResponse, error) {
+	group, ok := f.groups[id]
+	if !ok {
+		return nil, webclient.NotFoundError
+	}
+	return group, nil
+}
+
+func (f *fakeGroupsAPI) DeleteGroup(ctx context.Context, id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestGroupResourceCreate(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		createErr     error
+		wantID        string
+		wantAttrError string
+	}{
+		{
+			desc:   "the created group is saved to state",
+			wantID: "group-uid",
+		},
+		{
+			desc: "validation errors are reported on the attribute",
+			createErr: &webclient.APIError{
+				StatusCode:  400,
+				FieldErrors: []webclient.FieldError{{Field: "name", Message: "name is already taken"}},
+			},
+			wantAttrError: "name",
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			api := &fakeGroupsAPI{createErr: c.createErr}
+			r := NewGroupResource(testProvider(api))
+			s := resourceSchema(t, r)
+			config := map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, "Team Awesome"),
+				"members": stringSet("user-uid"),
+			}
+			resp := &resource.CreateResponse{State: emptyState(s)}
+			r.Create(context.Background(), resource.CreateRequest{Config: testConfig(t, s, config), Plan: testPlan(t, s, config)}, resp)
+
+			if c.wantAttrError != "" {
+				if len(resp.Diagnostics.Errors()) != 1 {
+					t.Fatalf("expected one error, got %v", resp.Diagnostics)
+				}
+				attributeErr, ok := resp.Diagnostics.Errors()[0].(interface{ Path() path.Path })
+				if !ok || !attributeErr.Path().Equal(path.Root(c.wantAttrError)) {
+					t.Fatalf("expected an error on %s, got %v", c.wantAttrError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if len(api.created) != 1 || api.created[0].Members[0] != "https://platform.local/api/users/user-uid" {
+				t.Errorf("expected the members to be sent as URLs, got %+v", api.created)
+			}
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+			if id.ValueString() != c.wantID {
+				t.Errorf("expected id %q in state, got %q", c.wantID, id.ValueString())
+			}
+		})
+	}
+}
+
+func TestGroupResourceReadRemovesMissingGroup(t *testing.T) {
+	api := &fakeGroupsAPI{groups: map[string]*webclient.GroupResponse{}}
+	r := NewGroupResource(testProvider(api))
+	s := resourceSchema(t, r)
+	state := testState(t, s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "group-uid"),
+		"name": tftypes.NewValue(tftypes.String, "Team Awesome"),
+	})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the group to be removed from state")
+	}
+}
+
+func TestGroupResourceDelete(t *testing.T) {
+	api := &fakeGroupsAPI{}
+	r := NewGroupResource(testProvider(api))
+	s := resourceSchema(t, r)
+	state := testState(t, s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "group-uid"),
+		"name": tftypes.NewValue(tftypes.String, "Team Awesome"),
+	})
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(api.deleted) != 1 || api.deleted[0] != "group-uid" {
+		t.Errorf("expected group-uid to be deleted, got %v", api.deleted)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -33,14 +34,15 @@ type schemaVersionResource struct {
 }
 
 type schemaVersionResourceData struct {
-	Body        types.String `tfsdk:"body"`
-	Version     types.String `tfsdk:"version"`
-	Description types.String `tfsdk:"description"`
-	Type        types.String `tfsdk:"type"`
-	Id          types.String `tfsdk:"id"`
-	SchemaId    types.String `tfsdk:"schema_id"`
-	FullName    types.String `tfsdk:"full_name"`
-	Owners      types.String `tfsdk:"owners"`
+	Body        types.String   `tfsdk:"body"`
+	Version     types.String   `tfsdk:"version"`
+	Description types.String   `tfsdk:"description"`
+	Type        types.String   `tfsdk:"type"`
+	Id          types.String   `tfsdk:"id"`
+	SchemaId    types.String   `tfsdk:"schema_id"`
+	FullName    types.String   `tfsdk:"full_name"`
+	Owners      types.String   `tfsdk:"owners"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *schemaVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -109,6 +111,9 @@ func (r *schemaVersionResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": createDeleteTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -122,8 +127,11 @@ func (r *schemaVersionResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	vsReq := createValidateSchemaVersionRequestFromData(ctx, &data)
-	valid, valErr := r.provider.client.ValidateSchemaVersion(vsReq)
+	valid, valErr := r.provider.client.ValidateSchemaVersion(ctx, vsReq)
 
 	const errorMsg = "Error message: %s"
 
@@ -137,7 +145,7 @@ func (r *schemaVersionResource) Create(ctx context.Context, req resource.CreateR
 		resp.Diagnostics.AddError("Error creating CREATE request struct for schemaVersion resource", fmt.Sprintf(errorMsg, err.Error()))
 		return
 	}
-	svResp, err := r.provider.client.CreateSchemaVersion(svReq)
+	svResp, err := r.provider.client.CreateSchemaVersion(ctx, svReq)
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for schema version resource", fmt.Sprintf(errorMsg, err.Error()))
 		return
@@ -160,7 +168,7 @@ func (r *schemaVersionResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	svResp, err := r.provider.client.GetSchemaVersion(data.Id.ValueString())
+	svResp, err := r.provider.client.GetSchemaVersion(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Schema version not found. Version ID: %s", data.Id.ValueString()))
@@ -171,7 +179,7 @@ func (r *schemaVersionResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 	tflog.Info(ctx, "Mapping the API response to the resource data")
-	newData := schemaVersionResourceData{}
+	newData := schemaVersionResourceData{Timeouts: data.Timeouts}
 	mapGetSchemaVersionResponseToData(ctx, &data, &newData, svResp, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
@@ -184,6 +192,9 @@ func (r *schemaVersionResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *schemaVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if updateTimeouts(ctx, req, resp) {
+		return
+	}
 	resp.Diagnostics.AddError("Client Error", "API does not allow update of schema version. Please create another version of the schema")
 }
 
@@ -197,7 +208,10 @@ func (r *schemaVersionResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	err := r.provider.client.DeleteSchemaVersion(data.Id.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteSchemaVersion(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("DELETE request error for schema version resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -220,7 +234,7 @@ func createValidateSchemaVersionRequestFromData(ctx context.Context, data *schem
 		r.Type = &schemaType
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("validating schema version request %q", r))
+	tflog.Info(ctx, fmt.Sprintf("validating schema version request %+v", r))
 	return r
 }
 
@@ -245,7 +259,7 @@ func createSchemaVersionRequestFromData(ctx context.Context, parsedSchema *webcl
 		if err != nil {
 			return webclient.SchemaVersionRequest{}, err
 		}
-		owners = fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, owners)
+		owners = fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), owners)
 		schemaVersionRequest.Owners = &owners
 	}
 
@@ -260,7 +274,7 @@ func createSchemaVersionRequestFromData(ctx context.Context, parsedSchema *webcl
 		schemaVersionRequest.Type = &schemaType
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("schema version request %q", schemaVersionRequest))
+	tflog.Info(ctx, fmt.Sprintf("schema version request %+v", schemaVersionRequest))
 	return schemaVersionRequest, nil
 }
 
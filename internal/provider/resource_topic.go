@@ -7,10 +7,10 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
-	"time"
 
 	custom_validator "axual.com/terraform-provider-axual/internal/custom-validator"
 	"axual.com/terraform-provider-axual/internal/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -26,6 +26,7 @@ import (
 
 var _ resource.Resource = &topicResource{}
 var _ resource.ResourceWithImportState = &topicResource{}
+var _ resource.ResourceWithIdentity = &topicResource{}
 
 func NewTopicResource(provider AxualProvider) resource.Resource {
 	return &topicResource{
@@ -37,24 +38,32 @@ type topicResource struct {
 	provider AxualProvider
 }
 
+// topicAPIFields are the attributes the API can report validation errors for.
+var topicAPIFields = []string{"name", "description", "key_type", "key_schema", "value_type", "value_schema", "owners", "viewers", "retention_policy", "properties"}
+
 type topicResourceData struct {
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	KeyType         types.String `tfsdk:"key_type"`
-	KeySchema       types.String `tfsdk:"key_schema"`
-	ValueType       types.String `tfsdk:"value_type"`
-	ValueSchema     types.String `tfsdk:"value_schema"`
-	Owners          types.String `tfsdk:"owners"`
-	Viewers         types.Set    `tfsdk:"viewers"`
-	RetentionPolicy types.String `tfsdk:"retention_policy"`
-	Id              types.String `tfsdk:"id"`
-	Properties      types.Map    `tfsdk:"properties"`
+	Name            types.String   `tfsdk:"name"`
+	Description     types.String   `tfsdk:"description"`
+	KeyType         types.String   `tfsdk:"key_type"`
+	KeySchema       types.String   `tfsdk:"key_schema"`
+	ValueType       types.String   `tfsdk:"value_type"`
+	ValueSchema     types.String   `tfsdk:"value_schema"`
+	Owners          types.String   `tfsdk:"owners"`
+	Viewers         types.Set      `tfsdk:"viewers"`
+	RetentionPolicy types.String   `tfsdk:"retention_policy"`
+	Id              types.String   `tfsdk:"id"`
+	Properties      types.Map      `tfsdk:"properties"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *topicResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_topic"
 }
 
+func (r *topicResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
 func (r *topicResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
@@ -135,6 +144,9 @@ func (r *topicResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -148,6 +160,9 @@ func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	topicRequest, err := createTopicRequestFromData(ctx, &data, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating CREATE request struct for topic resource", fmt.Sprintf("Error message: %s", err.Error()))
@@ -162,9 +177,9 @@ func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Create topic request %q", topicRequest))
-	topic, err := r.provider.client.CreateTopic(topicRequest)
+	topic, err := r.provider.client.CreateTopic(ctx, topicRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("CREATE request error for topic resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "CREATE request error for topic resource", fmt.Sprintf("Error message: %s", err.Error()), err, topicAPIFields...)
 		return
 	}
 
@@ -173,6 +188,7 @@ func (r *topicResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Info(ctx, "Saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *topicResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -185,7 +201,7 @@ func (r *topicResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	topic, err := r.provider.client.GetTopic(data.Id.ValueString())
+	topic, err := r.provider.client.GetTopic(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Topic not found. Id: %s", data.Id.ValueString()))
@@ -202,6 +218,7 @@ func (r *topicResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	tflog.Info(ctx, "Saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *topicResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -213,6 +230,10 @@ func (r *topicResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	topicRequest, err := createTopicRequestFromData(ctx, &data, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating UPDATE request struct for topic resource", fmt.Sprintf("Error message: %s", err.Error()))
@@ -234,9 +255,9 @@ func (r *topicResource) Update(ctx context.Context, req resource.UpdateRequest,
 	topicRequest.Properties = properties
 
 	tflog.Info(ctx, fmt.Sprintf("Update topic request %q", topicRequest))
-	topic, err := r.provider.client.UpdateTopic(data.Id.ValueString(), topicRequest)
+	topic, err := r.provider.client.UpdateTopic(ctx, data.Id.ValueString(), topicRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("UPDATE request error for topic resource", fmt.Sprintf("Error message: %s", err.Error()))
+		addAPIError(&resp.Diagnostics, "UPDATE request error for topic resource", fmt.Sprintf("Error message: %s", err.Error()), err, topicAPIFields...)
 		return
 	}
 
@@ -245,6 +266,7 @@ func (r *topicResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Info(ctx, "Saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *topicResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -257,18 +279,18 @@ func (r *topicResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Retry logic for deleting the topic to give time for Kafka to propagate changes
-	err := Retry(3, 3*time.Second, func() error {
-		return r.provider.client.DeleteTopic(data.Id.ValueString())
-	})
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteTopic(ctx, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("DELETE request error for topic resource", fmt.Sprintf("Error message after retries: %s", err.Error()))
+		resp.Diagnostics.AddError("DELETE request error for topic resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
 }
 
 func (r *topicResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func createTopicRequestFromData(ctx context.Context, data *topicResourceData, r *topicResource) (webclient.TopicRequest, error) {
@@ -281,13 +303,13 @@ func createTopicRequestFromData(ctx context.Context, data *topicResourceData, r
 	if err != nil {
 		return webclient.TopicRequest{}, err
 	}
-	owners = fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, owners)
+	owners = fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), owners)
 
 	var keySchema string
 	keyType := data.KeyType.ValueString()
 	if keyType == "AVRO" || keyType == "PROTOBUF" || keyType == "JSON_SCHEMA" {
 		if !data.KeySchema.IsNull() {
-			keySchema = fmt.Sprintf("%s/schemas/%v", r.provider.client.ApiURL, data.KeySchema.ValueString())
+			keySchema = fmt.Sprintf("%s/schemas/%v", r.provider.client.BaseURL(), data.KeySchema.ValueString())
 		} else {
 			return webclient.TopicRequest{}, fmt.Errorf("KeyType is %s but KeySchema is null", keyType)
 		}
@@ -297,7 +319,7 @@ func createTopicRequestFromData(ctx context.Context, data *topicResourceData, r
 	valueType := data.ValueType.ValueString()
 	if valueType == "AVRO" || valueType == "PROTOBUF" || valueType == "JSON_SCHEMA" {
 		if !data.ValueSchema.IsNull() {
-			valueSchema = fmt.Sprintf("%s/schemas/%v", r.provider.client.ApiURL, data.ValueSchema.ValueString())
+			valueSchema = fmt.Sprintf("%s/schemas/%v", r.provider.client.BaseURL(), data.ValueSchema.ValueString())
 		} else {
 			return webclient.TopicRequest{}, fmt.Errorf("ValueType is %s but ValueSchema is null", valueType)
 		}
@@ -312,7 +334,7 @@ func createTopicRequestFromData(ctx context.Context, data *topicResourceData, r
 		}
 
 		for _, viewer := range viewerUIDs {
-			fullURL := fmt.Sprintf("%s/groups/%v", r.provider.client.ApiURL, viewer)
+			fullURL := fmt.Sprintf("%s/groups/%v", r.provider.client.BaseURL(), viewer)
 			viewers = append(viewers, fullURL)
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,9 +29,10 @@ type topicBrowsePermissionsResource struct {
 }
 
 type topicBrowsePermissionsResourceData struct {
-	TopicConfig types.String `tfsdk:"topic_config"`
-	Users       types.Set    `tfsdk:"users"`
-	Groups      types.Set    `tfsdk:"groups"`
+	TopicConfig types.String   `tfsdk:"topic_config"`
+	Users       types.Set      `tfsdk:"users"`
+	Groups      types.Set      `tfsdk:"groups"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *topicBrowsePermissionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,6 +64,9 @@ func (r *topicBrowsePermissionsResource) Schema(ctx context.Context, req resourc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -75,13 +80,16 @@ func (r *topicBrowsePermissionsResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	permissionRequest, err := createPermissionRequestFromData(ctx, &data, r)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating permission request", fmt.Sprintf("Error message: %s", err.Error()))
 		return
 	}
 
-	err = r.provider.client.AddTopicConfigPermissions(data.TopicConfig.ValueString(), *permissionRequest)
+	err = r.provider.client.AddTopicConfigPermissions(ctx, data.TopicConfig.ValueString(), *permissionRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Error adding browse permissions", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -104,7 +112,7 @@ func (r *topicBrowsePermissionsResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	perms, err := r.provider.client.GetTopicConfigPermissions(data.TopicConfig.ValueString(), "browse")
+	perms, err := r.provider.client.GetTopicConfigPermissions(ctx, data.TopicConfig.ValueString(), "browse")
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading browse permissions", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -126,6 +134,9 @@ func (r *topicBrowsePermissionsResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	users := setToStringSlice(data.Users)
 	groups := setToStringSlice(data.Groups)
 
@@ -142,7 +153,7 @@ func (r *topicBrowsePermissionsResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	err = r.provider.client.DeleteTopicConfigPermissions(data.TopicConfig.ValueString(), *permissionRequest)
+	err = r.provider.client.DeleteTopicConfigPermissions(ctx, data.TopicConfig.ValueString(), *permissionRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting browse permissions", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -6,9 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"axual.com/terraform-provider-axual/internal/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -25,6 +25,7 @@ import (
 
 var _ resource.Resource = &topicConfigResource{}
 var _ resource.ResourceWithImportState = &topicConfigResource{}
+var _ resource.ResourceWithIdentity = &topicConfigResource{}
 
 func NewTopicConfigResource(provider AxualProvider) resource.Resource {
 	return &topicConfigResource{
@@ -36,22 +37,30 @@ type topicConfigResource struct {
 	provider AxualProvider
 }
 
+// topicConfigAPIFields are the attributes the API can report validation errors for.
+var topicConfigAPIFields = []string{"partitions", "retention_time", "topic:stream", "environment", "key_schema_version", "value_schema_version", "properties"}
+
 type topicConfigResourceData struct {
-	Partitions         types.Int64  `tfsdk:"partitions"`
-	RetentionTime      types.Int64  `tfsdk:"retention_time"`
-	Topic              types.String `tfsdk:"topic"`
-	Environment        types.String `tfsdk:"environment"`
-	KeySchemaVersion   types.String `tfsdk:"key_schema_version"`
-	ValueSchemaVersion types.String `tfsdk:"value_schema_version"`
-	Id                 types.String `tfsdk:"id"`
-	Properties         types.Map    `tfsdk:"properties"`
-	Force              types.Bool   `tfsdk:"force"`
+	Partitions         types.Int64    `tfsdk:"partitions"`
+	RetentionTime      types.Int64    `tfsdk:"retention_time"`
+	Topic              types.String   `tfsdk:"topic"`
+	Environment        types.String   `tfsdk:"environment"`
+	KeySchemaVersion   types.String   `tfsdk:"key_schema_version"`
+	ValueSchemaVersion types.String   `tfsdk:"value_schema_version"`
+	Id                 types.String   `tfsdk:"id"`
+	Properties         types.Map      `tfsdk:"properties"`
+	Force              types.Bool     `tfsdk:"force"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *topicConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_topic_config"
 }
 
+func (r *topicConfigResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema()
+}
+
 func (r *topicConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
@@ -111,6 +120,9 @@ func (r *topicConfigResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Force the update of topic configuration even in case of incompatible schema changes. Defaults to false.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 func (r *topicConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -123,7 +135,10 @@ func (r *topicConfigResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	topic, err := r.provider.client.GetTopic(data.Topic.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	topic, err := r.provider.client.GetTopic(ctx, data.Topic.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for topic config resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -136,7 +151,7 @@ func (r *topicConfigResource) Create(ctx context.Context, req resource.CreateReq
 				fmt.Sprintf("Topic doesn't have a schema-based Key Type (AVRO, PROTOBUF, or JSON_SCHEMA). Please don't set the KeySchemaVersion: %s", data.KeySchemaVersion.ValueString()))
 			return
 		} else {
-			r.validateSchemaVersionsForCreate(topic.Embedded.KeySchema.Uid, data.KeySchemaVersion.ValueString(), resp)
+			r.validateSchemaVersionsForCreate(ctx, topic.Embedded.KeySchema.Uid, data.KeySchemaVersion.ValueString(), resp)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -151,7 +166,7 @@ func (r *topicConfigResource) Create(ctx context.Context, req resource.CreateReq
 				fmt.Sprintf("Topic doesn't have a schema-based Value Type (AVRO, PROTOBUF, or JSON_SCHEMA). Please don't set the ValueSchemaVersion: %s", data.ValueSchemaVersion))
 			return
 		} else {
-			r.validateSchemaVersionsForCreate(topic.Embedded.ValueSchema.Uid, data.ValueSchemaVersion.ValueString(), resp)
+			r.validateSchemaVersionsForCreate(ctx, topic.Embedded.ValueSchema.Uid, data.ValueSchemaVersion.ValueString(), resp)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -164,17 +179,12 @@ func (r *topicConfigResource) Create(ctx context.Context, req resource.CreateReq
 		properties[key] = strings.Trim(value.String(), "\"")
 	}
 	topicConfigRequest.Properties = properties
-	tflog.Info(ctx, fmt.Sprintf("Create topic config request %q", topicConfigRequest))
+	tflog.Info(ctx, fmt.Sprintf("Create topic config request %+v", topicConfigRequest))
 
-	var topicConfig *webclient.TopicConfigResponse
-	// We retry to give time to Kafka to propagate changes
-	retryErr := Retry(4, 5*time.Second, func() (err error) {
-		topicConfig, err = r.provider.client.CreateTopicConfig(topicConfigRequest)
-		return err
-	})
-
-	if retryErr != nil {
-		resp.Diagnostics.AddError("CREATE request error for topic config resource", fmt.Sprintf("Error message after retries: %s", retryErr.Error()))
+	// Transient failures while Kafka propagates changes are retried by the client
+	topicConfig, err := r.provider.client.CreateTopicConfig(ctx, topicConfigRequest)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "CREATE request error for topic config resource", fmt.Sprintf("Error message: %s", err.Error()), err, topicConfigAPIFields...)
 		return
 	}
 
@@ -183,6 +193,7 @@ func (r *topicConfigResource) Create(ctx context.Context, req resource.CreateReq
 	tflog.Info(ctx, "Saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *topicConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -195,7 +206,7 @@ func (r *topicConfigResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	topicConfig, err := r.provider.client.ReadTopicConfig(data.Id.ValueString())
+	topicConfig, err := r.provider.client.ReadTopicConfig(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Topic config not found. Id: %s", data.Id.ValueString()))
@@ -212,6 +223,7 @@ func (r *topicConfigResource) Read(ctx context.Context, req resource.ReadRequest
 	tflog.Info(ctx, "saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -227,6 +239,9 @@ func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	if data.Topic != stateData.Topic {
 		resp.Diagnostics.AddError("Client Error", "API does not allow updating the topic field of a topic config. Please delete and recreate the resource.")
 		return
@@ -240,7 +255,7 @@ func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	topic, err := r.provider.client.GetTopic(data.Topic.ValueString())
+	topic, err := r.provider.client.GetTopic(ctx, data.Topic.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for topic config resource", fmt.Sprintf("Error message: %s", err.Error()))
 		return
@@ -253,7 +268,7 @@ func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateReq
 				fmt.Sprintf("Topic doesn't have a schema-based Key Type (AVRO, PROTOBUF, or JSON_SCHEMA). Please don't set the KeySchemaVersion: %s", data.KeySchemaVersion.ValueString()))
 			return
 		} else {
-			r.validateSchemaVersionsForUpdate(topic.Embedded.KeySchema.Uid, data.KeySchemaVersion.ValueString(), resp)
+			r.validateSchemaVersionsForUpdate(ctx, topic.Embedded.KeySchema.Uid, data.KeySchemaVersion.ValueString(), resp)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -268,7 +283,7 @@ func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateReq
 				fmt.Sprintf("Topic doesn't have a schema-based Value Type (AVRO, PROTOBUF, or JSON_SCHEMA). Please don't set the ValueSchemaVersion: %s", data.ValueSchemaVersion))
 			return
 		} else {
-			r.validateSchemaVersionsForUpdate(topic.Embedded.ValueSchema.Uid, data.ValueSchemaVersion.ValueString(), resp)
+			r.validateSchemaVersionsForUpdate(ctx, topic.Embedded.ValueSchema.Uid, data.ValueSchemaVersion.ValueString(), resp)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -295,18 +310,11 @@ func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateReq
 
 	topicConfigRequest.Properties = properties
 
-	tflog.Info(ctx, fmt.Sprintf("Update topic config request %q", topicConfigRequest))
-
-	// Retry logic for updating the topic config
-	var topicConfig *webclient.TopicConfigResponse
-	err = Retry(3, 2*time.Second, func() error {
-		var updateErr error
-		topicConfig, updateErr = r.provider.client.UpdateTopicConfig(data.Id.ValueString(), topicConfigRequest)
-		return updateErr
-	})
+	tflog.Info(ctx, fmt.Sprintf("Update topic config request %+v", topicConfigRequest))
 
+	topicConfig, err := r.provider.client.UpdateTopicConfig(ctx, data.Id.ValueString(), topicConfigRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update topic config after retries, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Client Error", fmt.Sprintf("Unable to update topic config, got error: %s", err), err, topicConfigAPIFields...)
 		return
 	}
 
@@ -315,6 +323,7 @@ func (r *topicConfigResource) Update(ctx context.Context, req resource.UpdateReq
 	tflog.Info(ctx, "Saving the resource to state")
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, data.Id)...)
 }
 
 func (r *topicConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -327,18 +336,18 @@ func (r *topicConfigResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	// Retry logic for deleting the topic config to give time for Kafka to propagate changes
-	err := Retry(3, 3*time.Second, func() error {
-		return r.provider.client.DeleteTopicConfig(data.Id.ValueString())
-	})
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteTopicConfig(ctx, data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete topic config after retries, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete topic config, got error: %s", err))
 		return
 	}
 }
 
 func (r *topicConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func createTopicConfigRequestFromData(ctx context.Context, data *topicConfigResourceData, r *topicConfigResource) (webclient.TopicConfigRequest, error) {
@@ -351,7 +360,7 @@ func createTopicConfigRequestFromData(ctx context.Context, data *topicConfigReso
 	if err != nil {
 		return webclient.TopicConfigRequest{}, err
 	}
-	topic = fmt.Sprintf("%s/streams/%v", r.provider.client.ApiURL, topic)
+	topic = fmt.Sprintf("%s/streams/%v", r.provider.client.BaseURL(), topic)
 
 	rawEnvironment, err := data.Environment.ToTerraformValue(ctx)
 	if err != nil {
@@ -362,7 +371,7 @@ func createTopicConfigRequestFromData(ctx context.Context, data *topicConfigReso
 	if err != nil {
 		return webclient.TopicConfigRequest{}, err
 	}
-	environment = fmt.Sprintf("%s/environments/%v", r.provider.client.ApiURL, environment)
+	environment = fmt.Sprintf("%s/environments/%v", r.provider.client.BaseURL(), environment)
 
 	topicConfigRequest := webclient.TopicConfigRequest{
 		Partitions:    int(data.Partitions.ValueInt64()),
@@ -373,10 +382,10 @@ func createTopicConfigRequestFromData(ctx context.Context, data *topicConfigReso
 
 	// optional fields
 	if !data.KeySchemaVersion.IsNull() {
-		topicConfigRequest.KeySchemaVersion = fmt.Sprintf("%s/schemas/%v", r.provider.client.ApiURL, data.KeySchemaVersion.ValueString())
+		topicConfigRequest.KeySchemaVersion = fmt.Sprintf("%s/schemas/%v", r.provider.client.BaseURL(), data.KeySchemaVersion.ValueString())
 	}
 	if !data.ValueSchemaVersion.IsNull() {
-		topicConfigRequest.ValueSchemaVersion = fmt.Sprintf("%s/schemas/%v", r.provider.client.ApiURL, data.ValueSchemaVersion.ValueString())
+		topicConfigRequest.ValueSchemaVersion = fmt.Sprintf("%s/schemas/%v", r.provider.client.BaseURL(), data.ValueSchemaVersion.ValueString())
 	}
 	if !data.Force.IsNull() {
 		topicConfigRequest.Force = data.Force.ValueBool()
@@ -408,8 +417,8 @@ func mapTopicConfigResponseToData(ctx context.Context, data *topicConfigResource
 	}
 }
 
-func (r *topicConfigResource) validateSchemaVersionsForUpdate(schemaUid string, schemaVersionUid string, resp *resource.UpdateResponse) {
-	keySchemaVersions, err := r.provider.client.GetSchemaVersionsBySchema(fmt.Sprintf("%s/schemas/%v", r.provider.client.ApiURL, schemaUid))
+func (r *topicConfigResource) validateSchemaVersionsForUpdate(ctx context.Context, schemaUid string, schemaVersionUid string, resp *resource.UpdateResponse) {
+	keySchemaVersions, err := r.provider.client.GetSchemaVersionsBySchema(ctx, fmt.Sprintf("%s/schemas/%v", r.provider.client.BaseURL(), schemaUid))
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for topic config resource",
 			fmt.Sprintf("Error message: %s", err.Error()))
@@ -431,8 +440,8 @@ func (r *topicConfigResource) validateSchemaVersionsForUpdate(schemaUid string,
 	}
 }
 
-func (r *topicConfigResource) validateSchemaVersionsForCreate(schemaUid string, schemaVersionUid string, resp *resource.CreateResponse) {
-	schemaVersions, err := r.provider.client.GetSchemaVersionsBySchema(fmt.Sprintf("%s/schemas/%v", r.provider.client.ApiURL, schemaUid))
+func (r *topicConfigResource) validateSchemaVersionsForCreate(ctx context.Context, schemaUid string, schemaVersionUid string, resp *resource.CreateResponse) {
+	schemaVersions, err := r.provider.client.GetSchemaVersionsBySchema(ctx, fmt.Sprintf("%s/schemas/%v", r.provider.client.BaseURL(), schemaUid))
 	if err != nil {
 		resp.Diagnostics.AddError("CREATE request error for topic config resource",
 			fmt.Sprintf("Error message: %s", err.Error()))
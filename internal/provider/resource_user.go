@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -29,14 +30,18 @@ type userResource struct {
 	provider AxualProvider
 }
 
+// userAPIFields are the attributes the API can report validation errors for.
+var userAPIFields = []string{"first_name", "middle_name", "last_name", "email_address", "phone_number", "roles"}
+
 type userResourceData struct {
-	FirstName    types.String `tfsdk:"first_name"`
-	MiddleName   types.String `tfsdk:"middle_name"`
-	LastName     types.String `tfsdk:"last_name"`
-	EmailAddress types.String `tfsdk:"email_address"`
-	PhoneNumber  types.String `tfsdk:"phone_number"`
-	Roles        []Role       `tfsdk:"roles"`
-	Id           types.String `tfsdk:"id"`
+	FirstName    types.String   `tfsdk:"first_name"`
+	MiddleName   types.String   `tfsdk:"middle_name"`
+	LastName     types.String   `tfsdk:"last_name"`
+	EmailAddress types.String   `tfsdk:"email_address"`
+	PhoneNumber  types.String   `tfsdk:"phone_number"`
+	Roles        []Role         `tfsdk:"roles"`
+	Id           types.String   `tfsdk:"id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 type Role struct {
@@ -94,6 +99,9 @@ func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -116,7 +124,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	user, err := r.provider.client.GetUser(data.Id.ValueString())
+	user, err := r.provider.client.GetUser(ctx, data.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, webclient.NotFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("User not found. Id: %s", data.Id.ValueString()))
@@ -145,11 +153,14 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	userRequest := createUserRequestFromData(ctx, &data)
 
-	user, err := r.provider.client.UpdateUser(data.Id.ValueString(), userRequest)
+	user, err := r.provider.client.UpdateUser(ctx, data.Id.ValueString(), userRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
+		addAPIError(&resp.Diagnostics, "Client Error", fmt.Sprintf("Unable to update user, got error: %s", err), err, userAPIFields...)
 		return
 	}
 
@@ -169,7 +180,10 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.provider.client.DeleteUser(data.Id.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.provider.client.DeleteUser(ctx, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
 		return
@@ -211,7 +225,7 @@ func createUserRequestFromData(ctx context.Context, data *userResourceData) webc
 	var roles []webclient.UserRole
 
 	for _, raw := range data.Roles {
-		roles = append(roles, webclient.UserRole{raw.Name.ValueString()})
+		roles = append(roles, webclient.UserRole{Name: raw.Name.ValueString()})
 	}
 	tflog.Info(ctx, fmt.Sprintf("Desired roles list size %d", len(data.Roles)))
 	tflog.Info(ctx, fmt.Sprintf("Creating new roles list of size %d", len(roles)))
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// defaultTimeout is the timeout of create, update and delete operations that are not configured in a timeouts block.
+const defaultTimeout = 10 * time.Minute
+
+// defaultDeploymentTimeout is the default timeout of application deployments, which wait for connectors and KSML apps to start.
+const defaultDeploymentTimeout = 20 * time.Minute
+
+// timeoutsBlock returns the timeouts block of a resource, with create, update and delete timeouts.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// createDeleteTimeoutsBlock returns the timeouts block of a resource that cannot be updated.
+func createDeleteTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Delete: true,
+	})
+}
+
+// updateTimeouts saves a planned change of a resource that cannot be updated when the change is limited to
+// its timeouts block, and reports whether it did. Other changes are left to the Update of the resource.
+func updateTimeouts(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) bool {
+	timeoutsPath := tftypes.NewAttributePath().WithAttributeName("timeouts")
+	planned, err := tftypes.Transform(req.Plan.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(timeoutsPath) {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		// Computed attributes are unknown in the plan of any change, and keep their value when only timeouts change.
+		if !v.IsKnown() {
+			if stateValue, _, err := tftypes.WalkAttributePath(req.State.Raw, p); err == nil {
+				if value, ok := stateValue.(tftypes.Value); ok {
+					return value, nil
+				}
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		return false
+	}
+	current, err := tftypes.Transform(req.State.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(timeoutsPath) {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil || !planned.Equal(current) {
+		return false
+	}
+
+	var plannedTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &plannedTimeouts)...)
+	resp.State.Raw = req.State.Raw
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("timeouts"), plannedTimeouts)...)
+	return true
+}
+
+// withTimeout returns the context of an operation, bounded by the configured timeout or the default.
+// The deadline is passed on to every API call and every wait for the platform in the operation.
+func withTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), defaultValue time.Duration, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	d, timeoutDiags := timeout(ctx, defaultValue)
+	diags.Append(timeoutDiags...)
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// timeoutsValue returns a timeouts block of the schema with the given create timeout.
+func timeoutsValue(s schema.Schema, create string) tftypes.Value {
+	timeoutsType := s.Type().TerraformType(context.Background()).(tftypes.Object).AttributeTypes["timeouts"].(tftypes.Object)
+	return tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+		"create": tftypes.NewValue(tftypes.String, create),
+		"delete": tftypes.NewValue(tftypes.String, nil),
+	})
+}
+
+func TestUpdateTimeouts(t *testing.T) {
+	state := map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "grant-uid"),
+		"status":      tftypes.NewValue(tftypes.String, "Approved"),
+		"application": tftypes.NewValue(tftypes.String, "app-uid"),
+		"topic":       tftypes.NewValue(tftypes.String, "topic-uid"),
+		"environment": tftypes.NewValue(tftypes.String, "env-uid"),
+		"access_type": tftypes.NewValue(tftypes.String, "Consumer"),
+	}
+	testCases := []struct {
+		desc        string
+		plan        map[string]tftypes.Value
+		wantUpdated bool
+	}{
+		{
+			desc:        "a change of only the timeouts is saved",
+			plan:        map[string]tftypes.Value{},
+			wantUpdated: true,
+		},
+		{
+			desc:        "computed attributes unknown in the plan keep their state",
+			plan:        map[string]tftypes.Value{"status": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+			wantUpdated: true,
+		},
+		{
+			desc: "a change of another attribute is left to the resource",
+			plan: map[string]tftypes.Value{"access_type": tftypes.NewValue(tftypes.String, "Producer")},
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			r := NewApplicationAccessGrantResource(testProvider(&fakeAPI{}))
+			s := resourceSchema(t, r)
+			current := map[string]tftypes.Value{"timeouts": timeoutsValue(s, "10m")}
+			planned := map[string]tftypes.Value{"timeouts": timeoutsValue(s, "30m")}
+			for name, value := range state {
+				current[name] = value
+				planned[name] = value
+			}
+			for name, value := range c.plan {
+				planned[name] = value
+			}
+			req := resource.UpdateRequest{Plan: testPlan(t, s, planned), State: testState(t, s, current)}
+			resp := &resource.UpdateResponse{State: emptyState(s)}
+
+			if updated := updateTimeouts(context.Background(), req, resp); updated != c.wantUpdated {
+				t.Fatalf("expected updated %t, got %t", c.wantUpdated, updated)
+			}
+			if !c.wantUpdated {
+				// The grant cannot be updated, so its Update reports the change as an error.
+				r.Update(context.Background(), req, resp)
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error for the change of the grant")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			var data applicationAccessGrantData
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+			if data.Id.ValueString() != "grant-uid" || data.Status.ValueString() != "Approved" || data.AccessType.ValueString() != "Consumer" {
+				t.Errorf("expected the state to be kept, got %+v", data)
+			}
+			var create types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("timeouts").AtName("create"), &create)...)
+			if create.ValueString() != "30m" {
+				t.Errorf("expected the planned create timeout, got %s", create)
+			}
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		create  types.String
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			desc:   "the configured timeout bounds the operation",
+			create: types.StringValue("1m"),
+			want:   time.Minute,
+		},
+		{
+			desc:   "the default bounds the operation without a configured timeout",
+			create: types.StringNull(),
+			want:   defaultTimeout,
+		},
+		{
+			desc:    "an invalid timeout is reported",
+			create:  types.StringValue("soon"),
+			want:    defaultTimeout,
+			wantErr: true,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.desc, func(t *testing.T) {
+			value := timeouts.Value{Object: types.ObjectValueMust(
+				map[string]attr.Type{"create": types.StringType},
+				map[string]attr.Value{"create": c.create},
+			)}
+			var diags diag.Diagnostics
+			start := time.Now()
+			ctx, cancel := withTimeout(context.Background(), value.Create, defaultTimeout, &diags)
+			defer cancel()
+
+			if diags.HasError() != c.wantErr {
+				t.Fatalf("expected an error %t, got %v", c.wantErr, diags)
+			}
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("expected a deadline")
+			}
+			if got := deadline.Sub(start); got < c.want || got > c.want+time.Second {
+				t.Errorf("expected a deadline after %s, got %s", c.want, got)
+			}
+		})
+	}
+}
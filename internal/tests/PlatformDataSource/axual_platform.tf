@@ -0,0 +1 @@
+data "axual_platform" "current" {}
@@ -0,0 +1,30 @@
+package PlatformDataSource
+
+import (
+	"testing"
+
+	. "axual.com/terraform-provider-axual/internal/tests"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPlatformDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: GetProviderConfig(t).ProtoV6ProviderFactories,
+		ExternalProviders:        GetProviderConfig(t).ExternalProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: GetProvider() + GetFile("axual_platform.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.axual_platform.current", "version"),
+					resource.TestCheckTypeSetElemAttr("data.axual_platform.current", "features.*", "instance_search_by_name"),
+					resource.TestCheckTypeSetElemAttr("data.axual_platform.current", "features.*", "principal_rotation"),
+				),
+			},
+			{
+				Destroy: true,
+				Config:  GetProvider(),
+			},
+		},
+	})
+}
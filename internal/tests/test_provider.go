@@ -1,17 +1,21 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	webclient "axual-webclient"
 
+	"axual.com/terraform-provider-axual/internal/fakeapi"
 	"axual.com/terraform-provider-axual/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -34,6 +38,42 @@ type ProviderConfig struct {
 	UserEmail         string `yaml:"userEmail"`
 	Username          string `yaml:"username"`
 	Password          string `yaml:"password"`
+	// CACertFile and InsecureSkipVerify configure TLS for platforms with a self-signed certificate
+	CACertFile         string `yaml:"caCertFile"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+	// FakeApi runs the tests against an in-memory fake of the API instead of a platform, see startFakeApi.
+	// The AXUAL_FAKE_API environment variable enables it as well.
+	FakeApi bool `yaml:"fakeApi"`
+	// Vcr is "record" to record the API traffic of each test to a cassette, or "replay" to replay it without
+	// a platform, see vcrProviderConfig. The AXUAL_VCR environment variable takes precedence.
+	Vcr string `yaml:"vcr"`
+}
+
+var (
+	fakeApiOnce sync.Once
+	fakeApiURL  string
+)
+
+// startFakeApi starts the fake API once per test process and returns its URL. It is seeded with the instance,
+// group and users the tests look up, as a platform prepared for the acceptance tests has them.
+func startFakeApi(config ProviderConfig) string {
+	fakeApiOnce.Do(func() {
+		seed := &fakeapi.State{
+			Instances: []*fakeapi.Instance{{Name: config.InstanceName, ShortName: config.InstanceShortName}},
+			Users: []*fakeapi.User{
+				{FirstName: "Test", LastName: "User", EmailAddress: config.UserEmail},
+				{FirstName: "Ben", LastName: "Foo", EmailAddress: "ben.foo@example.com"},
+			},
+			Groups: []*fakeapi.Group{{Name: config.GroupName}},
+		}
+		var options []fakeapi.Option
+		if config.Username != "" {
+			options = append(options, fakeapi.WithCredentials(config.Username, config.Password))
+		}
+		// The server lives as long as the test process.
+		fakeApiURL = httptest.NewServer(fakeapi.New(seed, options...)).URL
+	})
+	return fakeApiURL
 }
 
 // LoadProviderConfig Function to load the configuration from a YAML file
@@ -51,6 +91,17 @@ func LoadProviderConfig() (ProviderConfig, error) {
 	if config.Realm == "" {
 		config.Realm = "axual"
 	}
+	if os.Getenv("AXUAL_FAKE_API") != "" {
+		config.FakeApi = true
+	}
+	if vcr := os.Getenv("AXUAL_VCR"); vcr != "" {
+		config.Vcr = vcr
+	}
+	if config.FakeApi {
+		serverURL := startFakeApi(config)
+		config.ApiUrl = fakeapi.APIURL(serverURL)
+		config.AuthUrl = fakeapi.TokenURL(serverURL, config.Realm)
+	}
 	return config, nil
 }
 
@@ -96,9 +147,60 @@ func GetProviderConfig(t *testing.T) resource.TestCase {
 	if err != nil {
 		t.Fatalf("Error loading provider config: %v", err)
 	}
+	config, err := LoadProviderConfig()
+	if err != nil {
+		t.Fatalf("Error loading provider config: %v", err)
+	}
+	if config.Vcr != "" && config.Provider.Version == "local" {
+		return vcrProviderConfig(t, config, providerConfig)
+	}
 	return providerConfig
 }
 
+var (
+	vcrMu    sync.Mutex
+	vcrCases = map[string]resource.TestCase{}
+	// vcrClient is the client of the running test in record or replay mode, also used by the check helpers.
+	vcrClient *webclient.Client
+)
+
+// vcrProviderConfig runs the local provider with a client that records the API and token traffic of the test to
+// testdata/cassettes/<test name>.json in the test package, or replays it from there. Tests call GetProviderConfig
+// more than once, so the provider factories are kept per test; the cassette is saved when the test ends.
+func vcrProviderConfig(t *testing.T, config ProviderConfig, testCase resource.TestCase) resource.TestCase {
+	vcrMu.Lock()
+	defer vcrMu.Unlock()
+	if cached, ok := vcrCases[t.Name()]; ok {
+		return cached
+	}
+
+	cassette := filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	recorder, err := webclient.NewRecorder(cassette, webclient.RecorderMode(config.Vcr))
+	if err != nil {
+		t.Fatalf("Error opening cassette: %v", err)
+	}
+	client, err := newAPIClient(context.Background(), config, webclient.WithRecorder(recorder))
+	if err != nil {
+		t.Fatalf("Error creating API client: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("Error saving cassette %s: %v", cassette, err)
+		}
+		vcrMu.Lock()
+		defer vcrMu.Unlock()
+		delete(vcrCases, t.Name())
+		vcrClient = nil
+	})
+
+	vcrClient = client
+	testCase.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"axual": providerserver.NewProtocol6WithError(provider.NewWithClient("dev", client)()),
+	}
+	vcrCases[t.Name()] = testCase
+	return testCase
+}
+
 // Factory function for creating local provider instances
 func testAccProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
@@ -124,6 +226,8 @@ func GetProvider() string {
 		clientid = "self-service"
 		authurl  = "` + config.AuthUrl + `"
 		scopes   = ["openid", "profile", "email"]
+		ca_cert_file         = ` + hclString(config.CACertFile) + `
+		insecure_skip_verify = ` + fmt.Sprint(config.InsecureSkipVerify) + `
 	}
 	`
 
@@ -197,13 +301,26 @@ func CertPath(name string) string {
 // apiClient builds an authenticated webclient against the configured platform, mirroring the
 // provider block used by GetProvider (apiUrl/authUrl come from test_config.yaml). Used by check
 // helpers that must inspect live API state that the provider deliberately does not refresh into
-// Terraform state (e.g. a principal's activation status).
-func apiClient() (*webclient.Client, error) {
+// Terraform state (e.g. a principal's activation status). In record and replay mode it is the
+// client of the running test, so its requests are part of the cassette.
+func apiClient(ctx context.Context) (*webclient.Client, error) {
+	vcrMu.Lock()
+	client := vcrClient
+	vcrMu.Unlock()
+	if client != nil {
+		return client, nil
+	}
 	config, err := LoadProviderConfig()
 	if err != nil {
 		return nil, err
 	}
+	return newAPIClient(ctx, config)
+}
+
+// newAPIClient builds a webclient from the test config with the options.
+func newAPIClient(ctx context.Context, config ProviderConfig, options ...webclient.Option) (*webclient.Client, error) {
 	return webclient.NewClient(
+		ctx,
 		config.ApiUrl,
 		config.Realm,
 		webclient.AuthStruct{
@@ -214,9 +331,21 @@ func apiClient() (*webclient.Client, error) {
 			Scopes:   []string{"openid", "profile", "email"},
 			AuthMode: "keycloak",
 		},
+		append(options, webclient.WithTLSConfig(webclient.TLSConfig{
+			CACertFile:         config.CACertFile,
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		}))...,
 	)
 }
 
+// hclString renders an optional string for the provider block, null when empty.
+func hclString(value string) string {
+	if value == "" {
+		return "null"
+	}
+	return fmt.Sprintf("%q", value)
+}
+
 // CheckPrincipalActiveInAPI asserts the LIVE API activation status of the principal backing the
 // given resource (looked up by its state `id`). The provider treats `active` as write-only intent
 // and never refreshes it from the API, so state-based checks cannot observe activation inherited
@@ -232,13 +361,14 @@ func CheckPrincipalActiveInAPI(resourceName string, wantActive bool) resource.Te
 		if id == "" {
 			return fmt.Errorf("resource %q has empty id in state", resourceName)
 		}
-		client, err := apiClient()
+		ctx := context.Background()
+		client, err := apiClient(ctx)
 		if err != nil {
 			return fmt.Errorf("unable to build API client: %w", err)
 		}
 		var lastActive bool
 		for attempt := 0; attempt < 5; attempt++ {
-			p, err := client.ReadApplicationPrincipal(id)
+			p, err := client.ReadApplicationPrincipal(ctx, id)
 			if err != nil {
 				return fmt.Errorf("unable to read principal %s from API: %w", id, err)
 			}